@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"runtime/debug"
 	"strconv"
+	"time"
 
 	"github.com/CelineWuest/biscepter/internal/server"
 	"github.com/CelineWuest/biscepter/pkg/biscepter"
@@ -15,6 +16,13 @@ import (
 
 var bisectPort int
 var bisectConcurrency uint
+var bisectResume bool
+var bisectLeaseDuration time.Duration
+var bisectAddress string
+var bisectTLSCert, bisectTLSKey string
+var bisectToken, bisectTokenEnv string
+var bisectClientCA string
+var bisectServerType string
 
 var bisectCmd = &cobra.Command{
 	Use:   "bisect job.yml [replicas]",
@@ -23,9 +31,15 @@ var bisectCmd = &cobra.Command{
 This command optionally takes in an additional value for the amount of replicas should be launched.
 If no value for this is specified, it defaults to one replica.
 
-Calling this command results in a RESTful HTTP server being created, with whose API the issue(s) can be bisected.`,
+Calling this command results in a RESTful HTTP server being created, with whose API the issue(s) can be bisected.
+Pass --server grpc to create a gRPC server instead, whose service is specified in api/biscepter.proto.`,
 	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
+		serverType, err := server.ParseServerType(bisectServerType)
+		if err != nil {
+			logrus.Fatalf("Failed to select server - %v", err)
+		}
+
 		jobYaml, err := os.Open(args[0])
 		if err != nil {
 			logrus.Fatalf("Failed to open job yaml - %v", err)
@@ -71,13 +85,29 @@ Calling this command results in a RESTful HTTP server being created, with whose
 			}
 		}()
 
-		rsChan, ocChan, err := job.Run()
+		run := job.Run
+		if bisectResume {
+			run = job.Resume
+		}
+		rsChan, ocChan, err := run()
 		if err != nil {
 			logrus.Fatalf("Failed to start job - %v", err)
 		}
 
-		serverType := server.HTTP
-		err = server.NewServer(serverType, bisectPort, rsChan, ocChan)
+		token := resolveToken(bisectToken, bisectTokenEnv)
+
+		err = server.NewServer(serverType, server.Config{
+			Address: bisectAddress,
+			Port:    bisectPort,
+
+			TLSCertFile: bisectTLSCert,
+			TLSKeyFile:  bisectTLSKey,
+
+			Token:        token,
+			ClientCAFile: bisectClientCA,
+
+			LeaseDuration: bisectLeaseDuration,
+		}, job, rsChan, ocChan)
 		if err != nil {
 			logrus.Fatalf("Failed to start webserver - %v", err)
 		}
@@ -85,6 +115,11 @@ Calling this command results in a RESTful HTTP server being created, with whose
 		logrus.Infof("Job has finished, shutting down...")
 
 		jobDoneChan <- struct{}{}
+		summary := job.Summary()
+		if err := job.Stop(); err != nil {
+			logrus.Errorf("Failed to stop job - %v", err)
+		}
+		printSummary(summary)
 	},
 }
 
@@ -92,14 +127,37 @@ func init() {
 	rootCmd.AddCommand(bisectCmd)
 
 	bisectCmd.Flags().IntVarP(&bisectPort, "port", "p", 40032, "The port on which to start the server")
+	bisectCmd.Flags().StringVarP(&bisectServerType, "server", "s", "http", "The type of server to start, either http or grpc")
+	bisectCmd.Flags().StringVarP(&bisectAddress, "address", "a", "localhost", "The address on which to start the server. Use 0.0.0.0 to listen on all interfaces")
+	bisectCmd.Flags().StringVar(&bisectTLSCert, "tls-cert", "", "The path to the PEM encoded certificate of the server, enabling TLS")
+	bisectCmd.Flags().StringVar(&bisectTLSKey, "tls-key", "", "The path to the PEM encoded private key of the server's certificate")
+	bisectCmd.Flags().StringVar(&bisectToken, "token", "", "The bearer token clients have to authenticate with")
+	bisectCmd.Flags().StringVar(&bisectTokenEnv, "token-env", "", "The name of the environment variable holding the bearer token clients have to authenticate with. Takes precedence over --token")
+	bisectCmd.Flags().StringVar(&bisectClientCA, "client-ca", "", "The path to the PEM encoded CA certificates clients have to present a certificate of. Requires TLS")
 	bisectCmd.Flags().UintVarP(&bisectConcurrency, "max-concurrency", "c", 0, "The max amount of replicas that can run concurrently, or 0 if no limit")
+	bisectCmd.Flags().BoolVarP(&bisectResume, "resume", "r", false, "Resume the progress of a previous, interrupted run of the same job")
+	bisectCmd.Flags().DurationVarP(&bisectLeaseDuration, "lease", "l", 30*time.Minute, "How long running systems handed out by the server may go unrated before they are released, or 0 for no limit")
+}
+
+// resolveToken returns the bearer token clients have to authenticate with, read from the environment variable with the passed name if there is one
+func resolveToken(token string, tokenEnv string) string {
+	if tokenEnv == "" {
+		return token
+	}
+	token = os.Getenv(tokenEnv)
+	if token == "" {
+		logrus.Fatalf("Environment variable %s holding the token is empty", tokenEnv)
+	}
+	return token
 }
 
 func gracefulShutdown(job *biscepter.Job) {
+	summary := job.Summary()
 	if err := job.Stop(); err != nil {
 		logrus.Errorf("Failed to gracefully shut down job - %v", err)
 	} else {
 		logrus.Infof("Gracefully shut down job")
 	}
+	printSummary(summary)
 	os.Exit(1)
 }
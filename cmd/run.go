@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+
+	"github.com/CelineWuest/biscepter/pkg/biscepter"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run job.yml",
+	Short: "Automatically bisect an issue using the test of a job.yml",
+	Long: `Automatically bisect an issue using the test of a job.yml, similarly to git bisect run.
+The job config has to contain a test section, whose script is run against every system once it is healthy.
+An exit code of 0 marks the system as good, while the configured bad and broken exit codes mark it as bad or broken respectively.
+
+Once the bisection is done, the offending commit is printed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		jobYaml, err := os.Open(args[0])
+		if err != nil {
+			logrus.Fatalf("Failed to open job yaml - %v", err)
+		}
+		job, err := biscepter.GetJobFromConfig(jobYaml)
+		if err != nil {
+			logrus.Fatalf("Failed to read job config from yaml - %v", err)
+		}
+		if job.Test == nil {
+			logrus.Fatalf("Job config %s does not contain a test", args[0])
+		}
+
+		job.ReplicasCount = 1
+		job.Log = logrus.StandardLogger()
+
+		// Handle interrupts
+		jobDoneChan := make(chan struct{})
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		go func() {
+			select {
+			case <-ctx.Done():
+				logrus.Infof("Captured an interrupt signal, commencing graceful shutdown of job. Interrupt again to force shutdown.")
+				stop()
+				gracefulShutdown(job)
+			case <-jobDoneChan:
+			}
+		}()
+
+		// Handle panics
+		defer func() {
+			if r := recover(); r != nil {
+				logrus.Errorf("Captured a panic: %v", r)
+				logrus.Errorf("Stack trace: %s", debug.Stack())
+				logrus.Infof("Attempting to gracefully shut down job")
+				gracefulShutdown(job)
+			}
+		}()
+
+		rsChan, ocChan, err := job.Run()
+		if err != nil {
+			logrus.Fatalf("Failed to start job - %v", err)
+		}
+
+		for {
+			select {
+			case commit := <-ocChan:
+				printOffendingCommit(commit)
+
+				logrus.Infof("Job has finished, shutting down...")
+				jobDoneChan <- struct{}{}
+				if err := job.Stop(); err != nil {
+					logrus.Fatalf("Failed to stop job - %v", err)
+				}
+				return
+			case system := <-rsChan:
+				if _, err := system.RunTest(); err != nil {
+					logrus.Errorf("Failed to run test - %v", err)
+					gracefulShutdown(job)
+				}
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+}
+
+// printOffendingCommit prints the passed offending commit to stdout in a format similar to git bisect
+func printOffendingCommit(commit biscepter.OffendingCommit) {
+	fmt.Printf("%s is the first bad commit\n", commit.Commit)
+	if commit.Confidence < 1 {
+		fmt.Printf("Confidence: %.1f%%\n", commit.Confidence*100)
+	}
+	fmt.Printf("Author: %s\nDate:   %s\n\n%s\n", commit.CommitAuthor, commit.CommitDate, commit.CommitMessage)
+	if len(commit.PossibleOtherCommits) != 0 {
+		fmt.Printf("\nDue to broken commits, the first bad commit could also be any of: %v\n", commit.PossibleOtherCommits)
+	}
+}
+
+// printSummary prints the offending commits found by a job and the progress of its unfinished replicas to stdout
+func printSummary(summary biscepter.JobSummary) {
+	for i, commit := range summary.OffendingCommits {
+		if i != 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Replica %d: ", commit.ReplicaIndex)
+		printOffendingCommit(commit)
+	}
+	if len(summary.OffendingCommits) != 0 && len(summary.UnfinishedReplicas) != 0 {
+		fmt.Println()
+	}
+	for _, status := range summary.UnfinishedReplicas {
+		fmt.Printf("Replica %d didn't find its first bad commit, %d commits between good commit %s and bad commit %s remain\n", status.ReplicaIndex, status.RemainingCommits, status.GoodCommit, status.BadCommit)
+	}
+}
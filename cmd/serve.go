@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/CelineWuest/biscepter/internal/server"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var servePort int
+var serveConcurrency uint
+var serveLeaseDuration time.Duration
+var serveAddress string
+var serveTLSCert, serveTLSKey string
+var serveToken, serveTokenEnv string
+var serveClientCA string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start a long-running server to which multiple jobs can be submitted",
+	Long: `Start a long-running server to which multiple jobs can be submitted.
+Jobs are submitted by posting their job.yml to /jobs, after which they can be bisected using the same API as the one of the bisect command under /jobs/{id}.
+
+All jobs share one cache of built images and the limit of concurrently running replicas.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		token := resolveToken(serveToken, serveTokenEnv)
+
+		// Stop all jobs on interrupts
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		err := server.NewJobsServer(ctx, server.Config{
+			Address: serveAddress,
+			Port:    servePort,
+
+			TLSCertFile: serveTLSCert,
+			TLSKeyFile:  serveTLSKey,
+
+			Token:        token,
+			ClientCAFile: serveClientCA,
+
+			LeaseDuration: serveLeaseDuration,
+		}, server.JobsConfig{
+			MaxConcurrentReplicas: serveConcurrency,
+
+			Log: logrus.StandardLogger(),
+		})
+		if err != nil {
+			logrus.Fatalf("Failed to start webserver - %v", err)
+		}
+
+		logrus.Infof("Stopped all jobs, shutting down...")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().IntVarP(&servePort, "port", "p", 40032, "The port on which to start the server")
+	serveCmd.Flags().StringVarP(&serveAddress, "address", "a", "localhost", "The address on which to start the server. Use 0.0.0.0 to listen on all interfaces")
+	serveCmd.Flags().StringVar(&serveTLSCert, "tls-cert", "", "The path to the PEM encoded certificate of the server, enabling TLS")
+	serveCmd.Flags().StringVar(&serveTLSKey, "tls-key", "", "The path to the PEM encoded private key of the server's certificate")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "The bearer token clients have to authenticate with")
+	serveCmd.Flags().StringVar(&serveTokenEnv, "token-env", "", "The name of the environment variable holding the bearer token clients have to authenticate with. Takes precedence over --token")
+	serveCmd.Flags().StringVar(&serveClientCA, "client-ca", "", "The path to the PEM encoded CA certificates clients have to present a certificate of. Requires TLS")
+	serveCmd.Flags().UintVarP(&serveConcurrency, "max-concurrency", "c", 0, "The max amount of replicas of all jobs that can run concurrently, or 0 if no limit")
+	serveCmd.Flags().DurationVarP(&serveLeaseDuration, "lease", "l", 30*time.Minute, "How long running systems handed out by the server may go unrated before they are released, or 0 for no limit")
+}
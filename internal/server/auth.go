@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// validate returns an error if the TLS and authentication settings of the config are inconsistent
+func (config Config) validate() error {
+	if (config.TLSCertFile == "") != (config.TLSKeyFile == "") {
+		return fmt.Errorf("both a TLS certificate and key have to be specified")
+	}
+	if config.ClientCAFile != "" && config.TLSCertFile == "" {
+		return fmt.Errorf("authenticating clients by certificate requires TLS")
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS config of the server, requiring clients to present a certificate signed by the client CA if there is one.
+// Returns nil if the server doesn't use TLS.
+func (config Config) tlsConfig() (*tls.Config, error) {
+	if config.TLSCertFile == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.TLSCertFile, config.TLSKeyFile)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("couldn't load TLS certificate %s", config.TLSCertFile), err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if config.ClientCAFile != "" {
+		caPem, err := os.ReadFile(config.ClientCAFile)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("couldn't read client CA file %s", config.ClientCAFile), err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPem) {
+			return nil, fmt.Errorf("client CA file %s doesn't contain any PEM encoded certificates", config.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// authMiddleware returns a middleware aborting every request which doesn't authenticate itself as required by the config.
+// Requests have to pass the config's token as bearer token if there is one, and present a verified client certificate if there is a client CA.
+func (config Config) authMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if config.ClientCAFile != "" && (c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0) {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		if !config.validToken(c.GetHeader("Authorization")) {
+			c.Header("WWW-Authenticate", `Bearer realm="biscepter"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// validToken returns whether the passed authorization header passes the config's token as bearer token, or whether no token is required
+func (config Config) validToken(authorization string) bool {
+	if config.Token == "" {
+		return true
+	}
+	token, found := strings.CutPrefix(authorization, "Bearer ")
+	return found && subtle.ConstantTimeCompare([]byte(token), []byte(config.Token)) == 1
+}
+
+// authorize returns an UNAUTHENTICATED error if the gRPC call with the passed context doesn't authenticate itself as required by the config.
+// Calls have to pass the config's token as bearer token in their authorization metadata if there is one, and present a verified client certificate if there is a client CA.
+func (config Config) authorize(ctx context.Context) error {
+	if config.ClientCAFile != "" {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return status.Error(codes.Unauthenticated, "client certificate required")
+		}
+		tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+		if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+			return status.Error(codes.Unauthenticated, "client certificate required")
+		}
+	}
+
+	authorization := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(authorization) > 1 || !config.validToken(strings.Join(authorization, "")) {
+		return status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	return nil
+}
+
+// unaryAuthInterceptor returns an interceptor failing every unary gRPC call which doesn't authenticate itself as required by the config
+func (config Config) unaryAuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := config.authorize(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamAuthInterceptor returns an interceptor failing every streaming gRPC call which doesn't authenticate itself as required by the config
+func (config Config) streamAuthInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := config.authorize(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
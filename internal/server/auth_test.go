@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	values := []struct {
+		config        Config
+		authorization string
+
+		expectedCode int
+	}{
+		{Config{}, "", http.StatusOK},
+		{Config{Token: "secret"}, "Bearer secret", http.StatusOK},
+		{Config{Token: "secret"}, "", http.StatusUnauthorized},
+		{Config{Token: "secret"}, "Bearer wrong", http.StatusUnauthorized},
+		{Config{Token: "secret"}, "secret", http.StatusUnauthorized},
+		{Config{ClientCAFile: "ca.pem"}, "", http.StatusUnauthorized},
+	}
+
+	for i, v := range values {
+		router := gin.New()
+		router.Use(v.config.authMiddleware())
+		router.GET("/replicas", func(c *gin.Context) {
+			c.AbortWithStatus(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/replicas", nil)
+		if v.authorization != "" {
+			req.Header.Set("Authorization", v.authorization)
+		}
+		router.ServeHTTP(w, req)
+		assert.Equalf(t, v.expectedCode, w.Code, "Wrong status code for test %d; authorization: %q", i, v.authorization)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, Config{}.validate(), "Empty config is invalid")
+	assert.NoError(t, Config{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem", ClientCAFile: "ca.pem"}.validate(), "Config with mTLS is invalid")
+	assert.Error(t, Config{TLSCertFile: "cert.pem"}.validate(), "Config with certificate but without key is valid")
+	assert.Error(t, Config{ClientCAFile: "ca.pem"}.validate(), "Config with client CA but without TLS is valid")
+
+	_, err := Config{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem", ClientCAFile: path.Join(t.TempDir(), "ca.pem")}.tlsConfig()
+	assert.Error(t, err, "Missing client CA file didn't raise an error")
+}
+
+func TestAuthorize(t *testing.T) {
+	values := []struct {
+		config        Config
+		authorization []string
+
+		expectedCode codes.Code
+	}{
+		{Config{}, nil, codes.OK},
+		{Config{Token: "secret"}, []string{"Bearer secret"}, codes.OK},
+		{Config{Token: "secret"}, nil, codes.Unauthenticated},
+		{Config{Token: "secret"}, []string{"Bearer wrong"}, codes.Unauthenticated},
+		{Config{Token: "secret"}, []string{"Bearer wrong", "Bearer secret"}, codes.Unauthenticated},
+		{Config{ClientCAFile: "ca.pem"}, nil, codes.Unauthenticated},
+	}
+
+	for i, v := range values {
+		md := metadata.MD{}
+		md.Append("authorization", v.authorization...)
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		assert.Equalf(t, v.expectedCode, status.Code(v.config.authorize(ctx)), "Wrong code for test %d; authorization: %q", i, v.authorization)
+	}
+}
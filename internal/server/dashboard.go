@@ -0,0 +1,27 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+// registerDashboard serves the dashboard under the passed prefix followed by /dashboard/, e.g. /jobs/:id/dashboard/.
+// The dashboard only consists of static files and talks to the API relative to its own path, so it is registered before the auth middleware and prompts for the token itself instead.
+func registerDashboard(router gin.IRoutes, prefix string) {
+	files, _ := fs.Sub(dashboardFiles, "dashboard")
+	fileServer := http.FileServer(http.FS(files))
+
+	router.GET(prefix+"/dashboard/*filepath", func(c *gin.Context) {
+		c.Request.URL.Path = c.Param("filepath")
+		fileServer.ServeHTTP(c.Writer, c.Request)
+	})
+	router.GET(prefix+"/", func(c *gin.Context) {
+		c.Redirect(http.StatusFound, "dashboard/")
+	})
+}
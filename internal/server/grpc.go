@@ -0,0 +1,465 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/CelineWuest/biscepter/pkg/biscepter"
+	"github.com/CelineWuest/biscepter/pkg/biscepterpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcServer struct {
+	biscepterpb.UnimplementedBiscepterServer
+
+	job *biscepter.Job
+
+	rsChan chan biscepter.RunningSystem
+	ocChan chan biscepter.OffendingCommit
+
+	systems *systemRegistry               // The running systems handed out to clients
+	requeue func(biscepter.RunningSystem) // Called for running systems which could not be delivered to a client
+
+	// Channel used to exit the server
+	exitChan chan struct{}
+	// Context canceled once the server exits, used to end pending calls
+	ctx context.Context
+}
+
+func (g *grpcServer) init(config Config, job *biscepter.Job, rsChan chan biscepter.RunningSystem, ocChan chan biscepter.OffendingCommit) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return err
+	}
+	if config.Address == "" {
+		config.Address = "localhost"
+	}
+
+	g.job = job
+	g.rsChan = rsChan
+	g.ocChan = ocChan
+
+	g.systems = newSystemRegistry(config.LeaseDuration)
+	g.requeue = job.RequeueSystem
+
+	// Buffered, s.t. stopping the server doesn't block once it was signalled to exit
+	g.exitChan = make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	g.ctx = ctx
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(config.unaryAuthInterceptor()),
+		grpc.ChainStreamInterceptor(config.streamAuthInterceptor()),
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	grpcSrv := grpc.NewServer(opts...)
+	biscepterpb.RegisterBiscepterServer(grpcSrv, g)
+
+	addr := net.JoinHostPort(config.Address, fmt.Sprint(config.Port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		cancel()
+		return errors.Join(fmt.Errorf("server failed to listen on %s", addr), err)
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- grpcSrv.Serve(listener)
+	}()
+
+	select {
+	case <-g.exitChan:
+	case err := <-errChan:
+		cancel()
+		return errors.Join(fmt.Errorf("server failed to serve on %s", addr), err)
+	}
+	cancel()
+
+	grpcSrv.GracefulStop()
+	return nil
+}
+
+// portsMessage converts the passed ports to their message representation
+func portsMessage(ports map[int]int) map[int32]int32 {
+	msg := make(map[int32]int32)
+	for k, v := range ports {
+		msg[int32(k)] = int32(v)
+	}
+	return msg
+}
+
+func newRunningSystemMessage(id string, system biscepter.RunningSystem) *biscepterpb.RunningSystem {
+	msg := &biscepterpb.RunningSystem{
+		SystemId: id,
+
+		ReplicaIndex: int32(system.ReplicaIndex),
+		TrialIndex:   int32(system.TrialIndex),
+		Commit:       system.Commit(),
+
+		Ports: portsMessage(system.Ports),
+	}
+	if system.ServicePorts != nil {
+		msg.ServicePorts = make(map[string]*biscepterpb.ServicePorts)
+		for service, ports := range system.ServicePorts {
+			msg.ServicePorts[service] = &biscepterpb.ServicePorts{Ports: portsMessage(ports)}
+		}
+	}
+	return msg
+}
+
+func newOffendingCommitMessage(commit biscepter.OffendingCommit) *biscepterpb.OffendingCommit {
+	return &biscepterpb.OffendingCommit{
+		ReplicaIndex: int32(commit.ReplicaIndex),
+
+		Commit:       commit.Commit,
+		CommitOffset: int32(commit.CommitOffset),
+
+		CommitMessage: commit.CommitMessage,
+		CommitDate:    commit.CommitDate,
+		CommitAuthor:  commit.CommitAuthor,
+
+		MergeChain: commit.MergeChain,
+
+		OctopusMerge:        commit.OctopusMerge,
+		OctopusMergedBranch: commit.OctopusMergedBranch,
+
+		Confidence: commit.Confidence,
+	}
+}
+
+func newReplicaStatusMessage(replicaStatus biscepter.ReplicaStatus) *biscepterpb.ReplicaStatus {
+	return &biscepterpb.ReplicaStatus{
+		ReplicaIndex: int32(replicaStatus.ReplicaIndex),
+
+		GoodCommitOffset: int32(replicaStatus.GoodCommitOffset),
+		BadCommitOffset:  int32(replicaStatus.BadCommitOffset),
+
+		GoodCommit: replicaStatus.GoodCommit,
+		BadCommit:  replicaStatus.BadCommit,
+
+		RemainingCommits: int32(replicaStatus.RemainingCommits),
+		ExpectedRunsLeft: replicaStatus.ExpectedRunsLeft,
+
+		RunningCommit: replicaStatus.RunningCommit,
+
+		Finished: replicaStatus.Finished,
+		Stopped:  replicaStatus.Stopped,
+	}
+}
+
+func newEventMessage(event biscepter.Event) *biscepterpb.Event {
+	msg := &biscepterpb.Event{
+		Type: event.Type.String(),
+		Time: timestamppb.New(event.Time),
+
+		ReplicaIndex: int32(event.ReplicaIndex),
+		Commit:       event.Commit,
+
+		Replacement: event.Replacement,
+		Output:      event.Output,
+	}
+	if event.Type == biscepter.EventVerdictRecorded {
+		switch event.Verdict {
+		case biscepter.Good:
+			msg.Verdict = biscepterpb.Verdict_VERDICT_GOOD
+		case biscepter.Bad:
+			msg.Verdict = biscepterpb.Verdict_VERDICT_BAD
+		}
+	}
+	return msg
+}
+
+// registryError converts the passed error of the system registry into a gRPC status error
+func registryError(err error) error {
+	switch {
+	case errors.Is(err, errSystemNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errSystemExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, errConflictingVerdicts):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return err
+}
+
+// getSystem waits for the next running system or offending commit, of the requested replica if the request specifies one.
+// If neither got ready within the request's timeout, the response contains neither.
+// Also returns a function undoing the handout, which has to be called if the response couldn't be delivered to the client.
+func (g *grpcServer) getSystem(ctx context.Context, req *biscepterpb.GetSystemRequest) (*biscepterpb.GetSystemResponse, func(), error) {
+	// Wait for at most the passed timeout, or until the client is gone or the server exits if there is none
+	callCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer context.AfterFunc(g.ctx, cancel)()
+	if req.Timeout != nil {
+		if err := req.Timeout.CheckValid(); err != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout.AsDuration())
+		defer cancel()
+	}
+
+	if req.ReplicaIndex != nil {
+		system, commit, err := g.job.NextSystem(ctx, int(*req.ReplicaIndex))
+		switch {
+		case errors.Is(err, biscepter.ErrReplicaNotFound):
+			return nil, nil, status.Error(codes.NotFound, err.Error())
+		case err != nil && ctx.Err() != nil:
+			return g.doneError(callCtx)
+		case err != nil:
+			return nil, nil, status.Error(codes.FailedPrecondition, err.Error())
+		case commit != nil:
+			// The offending commit is kept by the replica, so it doesn't have to be requeued if it couldn't be delivered
+			return &biscepterpb.GetSystemResponse{
+				Result: &biscepterpb.GetSystemResponse_OffendingCommit{OffendingCommit: newOffendingCommitMessage(*commit)},
+			}, func() {}, nil
+		}
+
+		id := g.systems.register(*system)
+		return &biscepterpb.GetSystemResponse{
+			Result: &biscepterpb.GetSystemResponse_System{System: newRunningSystemMessage(id, *system)},
+		}, func() {
+			g.systems.unregister(id)
+			g.requeue(*system)
+		}, nil
+	}
+
+	select {
+	case commit := <-g.ocChan:
+		return &biscepterpb.GetSystemResponse{
+			Result: &biscepterpb.GetSystemResponse_OffendingCommit{OffendingCommit: newOffendingCommitMessage(commit)},
+		}, func() {
+			go func() {
+				g.ocChan <- commit
+			}()
+		}, nil
+	case system := <-g.rsChan:
+		id := g.systems.register(system)
+		return &biscepterpb.GetSystemResponse{
+			Result: &biscepterpb.GetSystemResponse_System{System: newRunningSystemMessage(id, system)},
+		}, func() {
+			g.systems.unregister(id)
+			g.requeue(system)
+		}, nil
+	case <-ctx.Done():
+		return g.doneError(callCtx)
+	}
+}
+
+// doneError returns the result of getSystem once waiting for a running system or offending commit is done without one being ready.
+// This is an empty response if the request's timeout was reached, and an error if the call ended or the server exits.
+func (g *grpcServer) doneError(callCtx context.Context) (*biscepterpb.GetSystemResponse, func(), error) {
+	switch {
+	case callCtx.Err() != nil:
+		return nil, nil, status.FromContextError(callCtx.Err()).Err()
+	case g.ctx.Err() != nil:
+		return nil, nil, status.Error(codes.Unavailable, "server is shutting down")
+	}
+	return &biscepterpb.GetSystemResponse{}, func() {}, nil
+}
+
+func (g *grpcServer) GetSystem(ctx context.Context, req *biscepterpb.GetSystemRequest) (*biscepterpb.GetSystemResponse, error) {
+	res, undo, err := g.getSystem(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		undo()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	return res, nil
+}
+
+// rate gives the running system with the requested ID the requested verdict.
+// Repeating a verdict succeeds without rating the system again, whereas giving a different verdict results in an ABORTED error.
+func (g *grpcServer) rate(req *biscepterpb.RateRequest) error {
+	var rate func(*biscepter.RunningSystem)
+	switch req.Verdict {
+	case biscepterpb.Verdict_VERDICT_GOOD:
+		rate = (*biscepter.RunningSystem).IsGood
+	case biscepterpb.Verdict_VERDICT_BAD:
+		rate = (*biscepter.RunningSystem).IsBad
+	case biscepterpb.Verdict_VERDICT_BROKEN:
+		rate = (*biscepter.RunningSystem).IsBroken
+	case biscepterpb.Verdict_VERDICT_SKIP:
+		rate = (*biscepter.RunningSystem).IsSkip
+	default:
+		return status.Errorf(codes.InvalidArgument, "%s is not a valid verdict", req.Verdict)
+	}
+
+	return registryError(g.systems.rate(req.SystemId, req.Verdict.String(), rate))
+}
+
+func (g *grpcServer) Rate(ctx context.Context, req *biscepterpb.RateRequest) (*biscepterpb.RateResponse, error) {
+	if err := g.rate(req); err != nil {
+		return nil, err
+	}
+	return &biscepterpb.RateResponse{}, nil
+}
+
+func (g *grpcServer) RenewLease(ctx context.Context, req *biscepterpb.RenewLeaseRequest) (*biscepterpb.RenewLeaseResponse, error) {
+	if err := g.systems.renew(req.SystemId); err != nil {
+		return nil, registryError(err)
+	}
+	return &biscepterpb.RenewLeaseResponse{}, nil
+}
+
+func (g *grpcServer) Bisect(stream biscepterpb.Biscepter_BisectServer) error {
+	// Receive in the background, s.t. idle streams end once the server exits
+	reqChan, errChan := make(chan *biscepterpb.BisectRequest), make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+			select {
+			case reqChan <- req:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		var req *biscepterpb.BisectRequest
+		select {
+		case req = <-reqChan:
+		case err := <-errChan:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-g.ctx.Done():
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+
+		switch req := req.Request.(type) {
+		case *biscepterpb.BisectRequest_GetSystem:
+			res, undo, err := g.getSystem(stream.Context(), req.GetSystem)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(&biscepterpb.BisectResponse{Response: &biscepterpb.BisectResponse_System{System: res}}); err != nil {
+				undo()
+				return err
+			}
+		case *biscepterpb.BisectRequest_Rate:
+			if err := g.rate(req.Rate); err != nil {
+				return err
+			}
+			if err := stream.Send(&biscepterpb.BisectResponse{Response: &biscepterpb.BisectResponse_Rated{Rated: &biscepterpb.RateResponse{}}}); err != nil {
+				return err
+			}
+		default:
+			return status.Error(codes.InvalidArgument, "request is neither get_system nor rate")
+		}
+	}
+}
+
+func (g *grpcServer) WatchEvents(req *biscepterpb.WatchEventsRequest, stream biscepterpb.Biscepter_WatchEventsServer) error {
+	events, unsubscribe := g.job.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(newEventMessage(event)); err != nil {
+				return err
+			}
+		case <-g.ctx.Done():
+			return nil
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (g *grpcServer) ListReplicas(ctx context.Context, req *biscepterpb.ListReplicasRequest) (*biscepterpb.ListReplicasResponse, error) {
+	statuses := g.job.ReplicaStatuses()
+	res := &biscepterpb.ListReplicasResponse{
+		Replicas: make([]*biscepterpb.ReplicaStatus, len(statuses)),
+	}
+	for i, replicaStatus := range statuses {
+		res.Replicas[i] = newReplicaStatusMessage(replicaStatus)
+	}
+	return res, nil
+}
+
+func (g *grpcServer) GetReplica(ctx context.Context, req *biscepterpb.GetReplicaRequest) (*biscepterpb.ReplicaStatus, error) {
+	replicaStatus, err := g.job.GetReplicaStatus(int(req.ReplicaIndex))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return newReplicaStatusMessage(replicaStatus), nil
+}
+
+func (g *grpcServer) AddReplica(ctx context.Context, req *biscepterpb.Issue) (*biscepterpb.AddReplicaResponse, error) {
+	index, err := g.job.AddReplica(biscepter.Issue{
+		GoodCommit:  req.GoodCommit,
+		BadCommit:   req.BadCommit,
+		GoodCommits: req.GoodCommits,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &biscepterpb.AddReplicaResponse{
+		ReplicaIndex: int32(index),
+	}, nil
+}
+
+func (g *grpcServer) CancelReplica(ctx context.Context, req *biscepterpb.CancelReplicaRequest) (*biscepterpb.CancelReplicaResponse, error) {
+	err := g.job.CancelReplica(int(req.ReplicaIndex))
+	switch {
+	case errors.Is(err, biscepter.ErrReplicaNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &biscepterpb.CancelReplicaResponse{}, nil
+}
+
+func (g *grpcServer) Stop(ctx context.Context, req *biscepterpb.StopRequest) (*biscepterpb.StopResponse, error) {
+	// Optionally wait for images being built, s.t. they can be reused by subsequent runs
+	if req.Wait {
+		if err := g.job.WaitForBuilds(ctx); err != nil {
+			// The client is gone, so the job is kept running
+			return nil, status.FromContextError(err).Err()
+		}
+	}
+
+	summary := g.job.Summary()
+	res := &biscepterpb.StopResponse{
+		OffendingCommits:   make([]*biscepterpb.OffendingCommit, len(summary.OffendingCommits)),
+		UnfinishedReplicas: make([]*biscepterpb.ReplicaStatus, len(summary.UnfinishedReplicas)),
+	}
+	for i, commit := range summary.OffendingCommits {
+		res.OffendingCommits[i] = newOffendingCommitMessage(commit)
+	}
+	for i, replicaStatus := range summary.UnfinishedReplicas {
+		res.UnfinishedReplicas[i] = newReplicaStatusMessage(replicaStatus)
+	}
+
+	// Signal the server to exit, unless a previous call already did
+	select {
+	case g.exitChan <- struct{}{}:
+	default:
+	}
+	return res, nil
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CelineWuest/biscepter/pkg/biscepter"
+	"github.com/CelineWuest/biscepter/pkg/biscepterpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newTestGRPCServer() *grpcServer {
+	return &grpcServer{
+		rsChan:  make(chan biscepter.RunningSystem),
+		ocChan:  make(chan biscepter.OffendingCommit),
+		systems: newSystemRegistry(0),
+		ctx:     context.Background(),
+	}
+}
+
+// idleBisectStream is a bisect stream whose client never sends a request
+type idleBisectStream struct {
+	biscepterpb.Biscepter_BisectServer
+	ctx context.Context
+}
+
+func (s idleBisectStream) Recv() (*biscepterpb.BisectRequest, error) {
+	<-s.ctx.Done()
+	return nil, s.ctx.Err()
+}
+
+func (s idleBisectStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestGRPCGetSystem(t *testing.T) {
+	g := newTestGRPCServer()
+
+	res, err := g.GetSystem(context.Background(), &biscepterpb.GetSystemRequest{Timeout: durationpb.New(10 * time.Millisecond)})
+	assert.NoError(t, err, "Timeout resulted in an error")
+	assert.Nil(t, res.Result, "Response after timeout isn't empty")
+
+	_, err = g.GetSystem(context.Background(), &biscepterpb.GetSystemRequest{Timeout: &durationpb.Duration{Seconds: 1, Nanos: -1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Invalid timeout didn't result in an invalid argument")
+
+	g.rsChan = make(chan biscepter.RunningSystem, 1)
+	g.rsChan <- biscepter.RunningSystem{ReplicaIndex: 3, Ports: map[int]int{80: 40000}}
+	res, err = g.GetSystem(context.Background(), &biscepterpb.GetSystemRequest{})
+	assert.NoError(t, err, "Getting ready system resulted in an error")
+	system := res.GetSystem()
+	if assert.NotNil(t, system, "Ready system wasn't delivered") {
+		assert.EqualValues(t, 3, system.ReplicaIndex, "Wrong system delivered")
+		assert.Equal(t, map[int32]int32{80: 40000}, system.Ports, "Wrong ports delivered")
+		assert.Contains(t, g.systems.systems, system.SystemId, "Delivered system wasn't registered")
+	}
+}
+
+func TestGRPCRate(t *testing.T) {
+	g := newTestGRPCServer()
+
+	_, err := g.Rate(context.Background(), &biscepterpb.RateRequest{SystemId: "unknown", Verdict: biscepterpb.Verdict_VERDICT_GOOD})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Rating unknown system didn't result in not found")
+
+	_, err = g.Rate(context.Background(), &biscepterpb.RateRequest{SystemId: "unknown"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Rating without verdict didn't result in an invalid argument")
+
+	assert.Equal(t, codes.Aborted, status.Code(registryError(errConflictingVerdicts)), "Conflicting verdicts weren't converted to aborted")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(registryError(errSystemExpired)), "Expired system wasn't converted to failed precondition")
+}
+
+func TestGRPCStop(t *testing.T) {
+	g := newTestGRPCServer()
+	g.job = &biscepter.Job{}
+	g.exitChan = make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	g.ctx = ctx
+
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	defer cancelStream()
+	bisected := make(chan error)
+	go func() { bisected <- g.Bisect(idleBisectStream{ctx: streamCtx}) }()
+
+	stopped := make(chan struct{})
+	go func() {
+		for range 2 {
+			_, err := g.Stop(context.Background(), &biscepterpb.StopRequest{})
+			assert.NoError(t, err, "Stopping resulted in an error")
+		}
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stopping twice blocked")
+	}
+
+	// Exiting the server cancels its context
+	<-g.exitChan
+	cancel()
+	select {
+	case err := <-bisected:
+		assert.Equal(t, codes.Unavailable, status.Code(err), "Idle bisect stream didn't end with unavailable")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Idle bisect stream didn't end once the server exited")
+	}
+}
@@ -1,59 +1,164 @@
 package server
 
 import (
+	"cmp"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/CelineWuest/biscepter/pkg/biscepter"
-	"github.com/dchest/uniuri"
 	"github.com/gin-gonic/gin"
 )
 
 type httpServer struct {
+	job *biscepter.Job
+
 	rsChan chan biscepter.RunningSystem
 	ocChan chan biscepter.OffendingCommit
 
-	rsMap map[string]biscepter.RunningSystem
+	systems *systemRegistry               // The running systems handed out to clients
+	requeue func(biscepter.RunningSystem) // Called for running systems which could not be delivered to a client
 
 	// Channel used to exit the server
 	exitChan chan struct{}
+	// Channel closed once the server exits, used to end streaming responses
+	doneChan chan struct{}
 }
 
-func (h *httpServer) init(port int, rsChan chan biscepter.RunningSystem, ocChan chan biscepter.OffendingCommit) error {
-	h.rsChan = rsChan
-	h.ocChan = ocChan
+// newHTTPServer returns a server handling the routes of the passed job, which hands out the systems received from the passed channels leased for the passed duration
+func newHTTPServer(job *biscepter.Job, rsChan chan biscepter.RunningSystem, ocChan chan biscepter.OffendingCommit, leaseDuration time.Duration) *httpServer {
+	return &httpServer{
+		job:    job,
+		rsChan: rsChan,
+		ocChan: ocChan,
+
+		systems: newSystemRegistry(leaseDuration),
+		requeue: job.RequeueSystem,
+
+		// Buffered, s.t. stopping the server doesn't block if it is exited otherwise at the same time
+		exitChan: make(chan struct{}, 1),
+		doneChan: make(chan struct{}),
+	}
+}
 
-	h.rsMap = make(map[string]biscepter.RunningSystem)
+func (h *httpServer) init(config Config, job *biscepter.Job, rsChan chan biscepter.RunningSystem, ocChan chan biscepter.OffendingCommit) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return err
+	}
+	if config.Address == "" {
+		config.Address = "localhost"
+	}
 
-	h.exitChan = make(chan struct{})
+	*h = *newHTTPServer(job, rsChan, ocChan, config.LeaseDuration)
 
 	router := gin.Default()
+	registerDashboard(router, "")
+	router.Use(config.authMiddleware())
 
-	router.GET("/system", h.getSystem)
-	router.POST("/isGood/:systemId", h.postIsGood)
-	router.POST("/isBad/:systemId", h.postIsBad)
-	router.POST("/stop", h.stop)
+	for _, route := range httpRoutes {
+		router.Handle(route.method, route.path, func(c *gin.Context) {
+			route.handler(h, c)
+		})
+	}
 
 	httpSrv := &http.Server{
-		Addr:    fmt.Sprintf("localhost:%d", port),
-		Handler: router,
+		Addr:      net.JoinHostPort(config.Address, fmt.Sprint(config.Port)),
+		Handler:   router,
+		TLSConfig: tlsConfig,
 	}
 
-	go httpSrv.ListenAndServe()
+	errChan := make(chan error, 1)
+	go func() {
+		if tlsConfig != nil {
+			errChan <- httpSrv.ListenAndServeTLS("", "")
+		} else {
+			errChan <- httpSrv.ListenAndServe()
+		}
+	}()
 
-	<-h.exitChan
+	select {
+	case <-h.exitChan:
+	case err := <-errChan:
+		return errors.Join(fmt.Errorf("server failed to listen on %s", httpSrv.Addr), err)
+	}
+	close(h.doneChan)
 
 	return httpSrv.Shutdown(context.Background())
 }
 
+// An httpRoute is a route of the HTTP API for bisecting a job
+type httpRoute struct {
+	method  string
+	path    string
+	handler func(*httpServer, *gin.Context)
+}
+
+// httpRoutes are the routes of the HTTP API for bisecting a job
+var httpRoutes = []httpRoute{
+	{http.MethodGet, "/system", (*httpServer).getSystem},
+	{http.MethodPost, "/isGood/:systemId", postVerdict("good", (*biscepter.RunningSystem).IsGood)},
+	{http.MethodPost, "/isBad/:systemId", postVerdict("bad", (*biscepter.RunningSystem).IsBad)},
+	{http.MethodPost, "/isBroken/:systemId", postVerdict("broken", (*biscepter.RunningSystem).IsBroken)},
+	{http.MethodPost, "/isSkip/:systemId", postVerdict("skip", (*biscepter.RunningSystem).IsSkip)},
+	{http.MethodPost, "/renew/:systemId", (*httpServer).postRenew},
+	{http.MethodPost, "/stop", (*httpServer).stop},
+	{http.MethodGet, "/events", (*httpServer).getEvents},
+	{http.MethodGet, "/replicas", (*httpServer).getReplicas},
+	{http.MethodGet, "/replicas/:index", (*httpServer).getReplica},
+	{http.MethodGet, "/replicas/:index/system", (*httpServer).getReplicaSystem},
+	{http.MethodGet, "/replicas/:index/commits", (*httpServer).getReplicaCommits},
+	{http.MethodGet, "/systems", (*httpServer).getSystems},
+	{http.MethodPost, "/replicas", (*httpServer).postReplica},
+	{http.MethodDelete, "/replicas/:index", (*httpServer).deleteReplica},
+}
+
 type runningSystemResponse struct {
 	SystemIndex string `json:"systemIndex"`
 
-	ReplicaIndex int `json:"replicaIndex"`
+	ReplicaIndex int    `json:"replicaIndex"`
+	TrialIndex   int    `json:"trialIndex"`
+	Commit       string `json:"commit"`
 
-	Ports map[string]string `json:"ports"`
+	Ports        map[string]string            `json:"ports"`
+	ServicePorts map[string]map[string]string `json:"servicePorts,omitempty"`
+}
+
+// stringPorts converts the passed ports to a map of strings, because JSON doesn't have int->int maps
+func stringPorts(ports map[int]int) map[string]string {
+	strPorts := make(map[string]string)
+	for k, v := range ports {
+		strPorts[fmt.Sprint(k)] = fmt.Sprint(v)
+	}
+	return strPorts
+}
+
+func newRunningSystemResponse(id string, system biscepter.RunningSystem) runningSystemResponse {
+	res := runningSystemResponse{
+		SystemIndex: id,
+
+		ReplicaIndex: system.ReplicaIndex,
+		TrialIndex:   system.TrialIndex,
+		Commit:       system.Commit(),
+
+		Ports: stringPorts(system.Ports),
+	}
+	if system.ServicePorts != nil {
+		res.ServicePorts = make(map[string]map[string]string)
+		for service, ports := range system.ServicePorts {
+			res.ServicePorts[service] = stringPorts(ports)
+		}
+	}
+	return res
 }
 
 type offendingCommitResponse struct {
@@ -65,74 +170,431 @@ type offendingCommitResponse struct {
 	CommitMessage string `json:"commitMessage"`
 	CommitDate    string `json:"commitDate"`
 	CommitAuthor  string `json:"commitAuthor"`
+
+	MergeChain []string `json:"mergeChain"`
+
+	OctopusMerge        string `json:"octopusMerge,omitempty"`
+	OctopusMergedBranch string `json:"octopusMergedBranch,omitempty"`
+
+	Confidence float64 `json:"confidence"`
+}
+
+func newOffendingCommitResponse(commit biscepter.OffendingCommit) offendingCommitResponse {
+	return offendingCommitResponse{
+		ReplicaIndex: commit.ReplicaIndex,
+
+		Commit:       commit.Commit,
+		CommitOffset: commit.CommitOffset,
+
+		CommitMessage: commit.CommitMessage,
+		CommitDate:    commit.CommitDate,
+		CommitAuthor:  commit.CommitAuthor,
+
+		MergeChain: commit.MergeChain,
+
+		OctopusMerge:        commit.OctopusMerge,
+		OctopusMergedBranch: commit.OctopusMergedBranch,
+
+		Confidence: commit.Confidence,
+	}
+}
+
+type issueRequest struct {
+	GoodCommit  string   `json:"goodCommit"`
+	BadCommit   string   `json:"badCommit"`
+	GoodCommits []string `json:"goodCommits"`
+}
+
+type replicaResponse struct {
+	ReplicaIndex int `json:"replicaIndex"`
+}
+
+type replicaStatusResponse struct {
+	ReplicaIndex int `json:"replicaIndex"`
+
+	GoodCommitOffset int `json:"goodCommitOffset"`
+	BadCommitOffset  int `json:"badCommitOffset"`
+
+	GoodCommit string `json:"goodCommit"`
+	BadCommit  string `json:"badCommit"`
+
+	RemainingCommits int     `json:"remainingCommits"`
+	ExpectedRunsLeft float64 `json:"expectedRunsLeft"`
+
+	RunningCommit string `json:"runningCommit,omitempty"`
+
+	Finished bool `json:"finished"`
+	Stopped  bool `json:"stopped"`
+}
+
+func newReplicaStatusResponse(status biscepter.ReplicaStatus) replicaStatusResponse {
+	return replicaStatusResponse{
+		ReplicaIndex: status.ReplicaIndex,
+
+		GoodCommitOffset: status.GoodCommitOffset,
+		BadCommitOffset:  status.BadCommitOffset,
+
+		GoodCommit: status.GoodCommit,
+		BadCommit:  status.BadCommit,
+
+		RemainingCommits: status.RemainingCommits,
+		ExpectedRunsLeft: status.ExpectedRunsLeft,
+
+		RunningCommit: status.RunningCommit,
+
+		Finished: status.Finished,
+		Stopped:  status.Stopped,
+	}
 }
 
 func (h *httpServer) getSystem(c *gin.Context) {
+	// Wait for at most the passed timeout, or until the client is gone if there is none
+	duration, ok := parseTimeout(c)
+	if !ok {
+		return
+	}
+	var timeout <-chan time.Time
+	if duration != 0 {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
 	select {
 	case commit := <-h.ocChan:
-		c.JSON(http.StatusOK, offendingCommitResponse{
-			ReplicaIndex: commit.ReplicaIndex,
+		if !h.respondOffendingCommit(c, commit) {
+			h.requeueOffendingCommit(commit)
+		}
+	case system := <-h.rsChan:
+		if !h.respondSystem(c, system) {
+			h.requeue(system)
+		}
+	case <-timeout:
+		c.AbortWithStatus(http.StatusNoContent)
+	case <-c.Request.Context().Done():
+		// The client is gone before anything was ready
+	}
+}
 
-			Commit:       commit.Commit,
-			CommitOffset: commit.CommitOffset,
+func (h *httpServer) getReplicaSystem(c *gin.Context) {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
 
-			CommitMessage: commit.CommitMessage,
-			CommitDate:    commit.CommitDate,
-			CommitAuthor:  commit.CommitAuthor,
-		})
-	case system := <-h.rsChan:
-		// Register ID
-		id := uniuri.New()
-		h.rsMap[id] = system
-
-		// Convert ports to map of strings because JSON doesn't have int->int maps
-		strPorts := make(map[string]string)
-		for k, v := range system.Ports {
-			strPorts[fmt.Sprint(k)] = fmt.Sprint(v)
+	// Wait for at most the passed timeout, or until the client is gone if there is none
+	duration, ok := parseTimeout(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	if duration != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	system, commit, err := h.job.NextSystem(ctx, index)
+	switch {
+	case errors.Is(err, biscepter.ErrReplicaNotFound):
+		c.AbortWithStatus(http.StatusNotFound)
+	case errors.Is(err, context.DeadlineExceeded):
+		c.AbortWithStatus(http.StatusNoContent)
+	case errors.Is(err, context.Canceled):
+		// The client is gone before anything was ready
+	case err != nil:
+		c.AbortWithError(http.StatusConflict, err)
+	case commit != nil:
+		// The offending commit is kept by the replica, so it doesn't have to be requeued if it couldn't be delivered
+		h.respondOffendingCommit(c, *commit)
+	default:
+		if !h.respondSystem(c, *system) {
+			h.requeue(*system)
+		}
+	}
+}
+
+// parseTimeout returns the duration passed as timeout query parameter, or 0 if there is none.
+// If the timeout is not a valid duration, the request is aborted and false is returned.
+func parseTimeout(c *gin.Context) (time.Duration, bool) {
+	timeoutParam := c.Query("timeout")
+	if timeoutParam == "" {
+		return 0, true
+	}
+	duration, err := time.ParseDuration(timeoutParam)
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return 0, false
+	}
+	return duration, true
+}
+
+// respondOffendingCommit responds with the passed offending commit and returns whether it was delivered to the client
+func (h *httpServer) respondOffendingCommit(c *gin.Context, commit biscepter.OffendingCommit) bool {
+	if c.Request.Context().Err() != nil {
+		return false
+	}
+
+	c.JSON(http.StatusOK, newOffendingCommitResponse(commit))
+	return len(c.Errors) == 0
+}
+
+// respondSystem registers the passed running system and responds with it, returning whether it was delivered to the client.
+// If it wasn't delivered, the running system is not registered.
+func (h *httpServer) respondSystem(c *gin.Context, system biscepter.RunningSystem) bool {
+	if c.Request.Context().Err() != nil {
+		return false
+	}
+
+	// Register ID
+	id := h.systems.register(system)
+
+	c.JSON(http.StatusOK, newRunningSystemResponse(id, system))
+	if len(c.Errors) != 0 {
+		h.systems.unregister(id)
+		return false
+	}
+	return true
+}
+
+// requeueOffendingCommit puts the passed offending commit, which could not be delivered to a client, back into the offending commit channel
+func (h *httpServer) requeueOffendingCommit(commit biscepter.OffendingCommit) {
+	go func() {
+		h.ocChan <- commit
+	}()
+}
+
+// postVerdict returns a handler rating the running system with the ID passed in the path with the passed verdict, using the passed function.
+// Repeating a verdict succeeds without rating the system again, whereas giving a different verdict results in a conflict.
+func postVerdict(verdict string, rate func(*biscepter.RunningSystem)) func(*httpServer, *gin.Context) {
+	return func(h *httpServer, c *gin.Context) {
+		err := h.systems.rate(c.Param("systemId"), verdict, rate)
+		switch {
+		case errors.Is(err, errSystemNotFound):
+			c.AbortWithStatus(http.StatusNotFound)
+		case errors.Is(err, errSystemExpired):
+			c.AbortWithStatus(http.StatusGone)
+		case errors.Is(err, errConflictingVerdicts):
+			c.AbortWithStatus(http.StatusConflict)
+		default:
+			c.AbortWithStatus(http.StatusOK)
 		}
+	}
+}
 
-		out, _ := json.Marshal(runningSystemResponse{
-			SystemIndex: id,
+func (h *httpServer) postRenew(c *gin.Context) {
+	err := h.systems.renew(c.Param("systemId"))
+	switch {
+	case errors.Is(err, errSystemNotFound):
+		c.AbortWithStatus(http.StatusNotFound)
+	case errors.Is(err, errSystemExpired):
+		c.AbortWithStatus(http.StatusGone)
+	default:
+		c.AbortWithStatus(http.StatusOK)
+	}
+}
 
-			ReplicaIndex: system.ReplicaIndex,
+func (h *httpServer) postReplica(c *gin.Context) {
+	var issue issueRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&issue); err != nil {
+			return
+		}
+	}
 
-			Ports: strPorts,
-		})
-		fmt.Print(string(out))
+	index, err := h.job.AddReplica(biscepter.Issue{
+		GoodCommit:  issue.GoodCommit,
+		BadCommit:   issue.BadCommit,
+		GoodCommits: issue.GoodCommits,
+	})
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
 
-		c.JSON(http.StatusOK, runningSystemResponse{
-			SystemIndex: id,
+	c.JSON(http.StatusOK, replicaResponse{
+		ReplicaIndex: index,
+	})
+}
 
-			ReplicaIndex: system.ReplicaIndex,
+type eventResponse struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
 
-			Ports: strPorts,
-		})
+	ReplicaIndex int    `json:"replicaIndex"`
+	Commit       string `json:"commit"`
+
+	Replacement string `json:"replacement,omitempty"`
+	Verdict     string `json:"verdict,omitempty"`
+	Output      string `json:"output,omitempty"`
+}
+
+func newEventResponse(event biscepter.Event) eventResponse {
+	res := eventResponse{
+		Type: event.Type.String(),
+		Time: event.Time,
+
+		ReplicaIndex: event.ReplicaIndex,
+		Commit:       event.Commit,
+
+		Replacement: event.Replacement,
+		Output:      event.Output,
+	}
+	if event.Type == biscepter.EventVerdictRecorded {
+		res.Verdict = event.Verdict.String()
 	}
+	return res
 }
 
-func (h *httpServer) postIsGood(c *gin.Context) {
-	id := c.Param("systemId")
-	if rs, found := h.rsMap[id]; found {
-		rs.IsGood()
-		delete(h.rsMap, id)
-		c.AbortWithStatus(200)
-	} else {
-		c.AbortWithStatus(404)
+func (h *httpServer) getEvents(c *gin.Context) {
+	events, unsubscribe := h.job.Subscribe()
+	defer unsubscribe()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type.String(), newEventResponse(event))
+			return true
+		case <-h.doneChan:
+			return false
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func (h *httpServer) getReplicas(c *gin.Context) {
+	statuses := h.job.ReplicaStatuses()
+	res := make([]replicaStatusResponse, len(statuses))
+	for i, status := range statuses {
+		res[i] = newReplicaStatusResponse(status)
 	}
+	c.JSON(http.StatusOK, res)
 }
 
-func (h *httpServer) postIsBad(c *gin.Context) {
-	id := c.Param("systemId")
-	if rs, found := h.rsMap[id]; found {
-		rs.IsBad()
-		delete(h.rsMap, id)
-		c.AbortWithStatus(200)
+func (h *httpServer) getReplica(c *gin.Context) {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	status, err := h.job.GetReplicaStatus(index)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	c.JSON(http.StatusOK, newReplicaStatusResponse(status))
+}
+
+type replicaCommitResponse struct {
+	Commit string `json:"commit"`
+
+	Built bool `json:"built"`
+
+	Replacement string `json:"replacement,omitempty"`
+	Skipped     bool   `json:"skipped"`
+}
+
+func (h *httpServer) getReplicaCommits(c *gin.Context) {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	commits, err := h.job.ReplicaCommits(index)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	res := make([]replicaCommitResponse, len(commits))
+	for i, commit := range commits {
+		res[i] = replicaCommitResponse{
+			Commit: commit.Hash,
+
+			Built: commit.Built,
+
+			Replacement: commit.Replacement,
+			Skipped:     commit.Skipped,
+		}
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *httpServer) getSystems(c *gin.Context) {
+	systems := h.systems.handedOut()
+	res := make([]runningSystemResponse, 0, len(systems))
+	for id, system := range systems {
+		res = append(res, newRunningSystemResponse(id, system))
+	}
+	slices.SortFunc(res, func(a, b runningSystemResponse) int {
+		return cmp.Or(cmp.Compare(a.ReplicaIndex, b.ReplicaIndex), cmp.Compare(a.TrialIndex, b.TrialIndex))
+	})
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *httpServer) deleteReplica(c *gin.Context) {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	if err := h.job.CancelReplica(index); errors.Is(err, biscepter.ErrReplicaNotFound) {
+		c.AbortWithStatus(http.StatusNotFound)
+	} else if err != nil {
+		c.AbortWithError(http.StatusConflict, err)
 	} else {
-		c.AbortWithStatus(404)
+		c.AbortWithStatus(http.StatusOK)
 	}
 }
 
+type summaryResponse struct {
+	OffendingCommits   []offendingCommitResponse `json:"offendingCommits"`
+	UnfinishedReplicas []replicaStatusResponse   `json:"unfinishedReplicas"`
+}
+
+func newSummaryResponse(summary biscepter.JobSummary) summaryResponse {
+	res := summaryResponse{
+		OffendingCommits:   make([]offendingCommitResponse, len(summary.OffendingCommits)),
+		UnfinishedReplicas: make([]replicaStatusResponse, len(summary.UnfinishedReplicas)),
+	}
+	for i, commit := range summary.OffendingCommits {
+		res.OffendingCommits[i] = newOffendingCommitResponse(commit)
+	}
+	for i, status := range summary.UnfinishedReplicas {
+		res.UnfinishedReplicas[i] = newReplicaStatusResponse(status)
+	}
+	return res
+}
+
 func (h *httpServer) stop(c *gin.Context) {
-	c.AbortWithStatus(200)
-	h.exitChan <- struct{}{}
+	wait := false
+	if waitParam := c.Query("wait"); waitParam != "" {
+		var err error
+		if wait, err = strconv.ParseBool(waitParam); err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	// Optionally wait for images being built, s.t. they can be reused by subsequent runs
+	if wait {
+		if err := h.job.WaitForBuilds(c.Request.Context()); err != nil {
+			// The client is gone, so the job is kept running
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, newSummaryResponse(h.job.Summary()))
+	// Signal the server to exit, unless a previous request already did
+	select {
+	case h.exitChan <- struct{}{}:
+	default:
+	}
 }
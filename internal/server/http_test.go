@@ -0,0 +1,106 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/CelineWuest/biscepter/pkg/biscepter"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer() *httpServer {
+	return &httpServer{
+		rsChan:  make(chan biscepter.RunningSystem),
+		ocChan:  make(chan biscepter.OffendingCommit),
+		systems: newSystemRegistry(0),
+	}
+}
+
+func TestGetSystemTimeout(t *testing.T) {
+	h := newTestServer()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/system?timeout=10ms", nil)
+	h.getSystem(c)
+	assert.Equal(t, http.StatusNoContent, w.Code, "Wrong status code after timeout")
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/system?timeout=soon", nil)
+	h.getSystem(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Invalid timeout didn't result in a bad request")
+}
+
+// failingWriter is a response writer to which writing the body always fails, as if the client was gone
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("client is gone")
+}
+
+func TestGetSystemRequeue(t *testing.T) {
+	h := newTestServer()
+	var requeued []biscepter.RunningSystem
+	h.requeue = func(system biscepter.RunningSystem) {
+		requeued = append(requeued, system)
+	}
+
+	h.rsChan = make(chan biscepter.RunningSystem, 1)
+	h.rsChan <- biscepter.RunningSystem{ReplicaIndex: 3}
+	c, _ := gin.CreateTestContext(failingWriter{httptest.NewRecorder()})
+	c.Request = httptest.NewRequest(http.MethodGet, "/system", nil)
+	h.getSystem(c)
+
+	assert.Equal(t, []biscepter.RunningSystem{{ReplicaIndex: 3}}, requeued, "Undelivered system wasn't requeued")
+	assert.Empty(t, h.systems.systems, "Undelivered system is still registered")
+}
+
+func TestDashboard(t *testing.T) {
+	router := gin.New()
+	registerDashboard(router, "/jobs/:id")
+	router.Use(Config{Token: "secret"}.authMiddleware())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs/abc/dashboard/", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "Dashboard isn't served without a token")
+	assert.Contains(t, w.Body.String(), "<title>biscepter</title>", "Wrong dashboard page served")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs/abc/", nil))
+	assert.Equal(t, http.StatusFound, w.Code, "Root doesn't redirect to the dashboard")
+	assert.Equal(t, "/jobs/abc/dashboard/", w.Header().Get("Location"), "Root doesn't redirect to the dashboard")
+}
+
+func TestStopTwice(t *testing.T) {
+	h := newHTTPServer(&biscepter.Job{}, nil, nil, 0)
+
+	stopped := make(chan struct{})
+	go func() {
+		for range 2 {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/stop", nil)
+			h.stop(c)
+			assert.Equal(t, http.StatusOK, w.Code, "Stopping didn't respond with the summary")
+		}
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stopping twice blocked")
+	}
+
+	select {
+	case <-h.exitChan:
+	default:
+		t.Error("Stopping didn't signal the server to exit")
+	}
+}
@@ -0,0 +1,373 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/CelineWuest/biscepter/pkg/biscepter"
+	"github.com/dchest/uniuri"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
+)
+
+// JobsConfig holds the settings of a server hosting multiple jobs
+type JobsConfig struct {
+	MaxConcurrentReplicas uint // The max amount of replicas of all jobs that can run concurrently, or 0 if no limit
+
+	Log *logrus.Logger // The log to which the jobs print information
+}
+
+type jobsServer struct {
+	config     Config
+	jobsConfig JobsConfig
+
+	images    *biscepter.ImageCache // The image cache shared by all jobs
+	semaphore *semaphore.Weighted   // The semaphore limiting the concurrently running replicas of all jobs
+
+	mutex sync.Mutex
+	jobs  map[string]*hostedJob
+}
+
+// A hostedJob is a job submitted to a jobs server
+type hostedJob struct {
+	id        string
+	submitted time.Time
+
+	job *biscepter.Job
+	dir string // The directory holding the job's state and commit replacements
+
+	server *httpServer // The server handling the job's routes, or nil if the job didn't start yet
+	err    error       // The error with which the job failed to start
+
+	deleted bool // Whether the job was deleted before it started
+}
+
+// NewJobsServer starts an HTTP server to which jobs can be submitted, and which exposes the API for bisecting each of them under /jobs/{id}.
+// All jobs share one image cache and are limited to the configured amount of concurrently running replicas.
+// The server runs until the passed context is done, at which point all its jobs are stopped.
+func NewJobsServer(ctx context.Context, config Config, jobsConfig JobsConfig) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return err
+	}
+	if config.Address == "" {
+		config.Address = "localhost"
+	}
+	if jobsConfig.Log == nil {
+		jobsConfig.Log = logrus.StandardLogger()
+	}
+
+	images, err := biscepter.NewImageCache()
+	if err != nil {
+		return err
+	}
+	maxConcurrentReplicas := int64(jobsConfig.MaxConcurrentReplicas)
+	if maxConcurrentReplicas == 0 {
+		maxConcurrentReplicas = math.MaxInt64
+	}
+
+	s := &jobsServer{
+		config:     config,
+		jobsConfig: jobsConfig,
+
+		images:    images,
+		semaphore: semaphore.NewWeighted(maxConcurrentReplicas),
+
+		jobs: make(map[string]*hostedJob),
+	}
+
+	router := gin.Default()
+	registerDashboard(router, "/jobs/:id")
+	router.Use(config.authMiddleware())
+
+	router.POST("/jobs", s.postJob)
+	router.GET("/jobs", s.getJobs)
+	router.GET("/jobs/:id", s.getJob)
+	router.DELETE("/jobs/:id", s.deleteJob)
+	for _, route := range httpRoutes {
+		router.Handle(route.method, "/jobs/:id"+route.path, s.forJob(route.handler))
+	}
+
+	httpSrv := &http.Server{
+		Addr:      net.JoinHostPort(config.Address, fmt.Sprint(config.Port)),
+		Handler:   router,
+		TLSConfig: tlsConfig,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		if tlsConfig != nil {
+			errChan <- httpSrv.ListenAndServeTLS("", "")
+		} else {
+			errChan <- httpSrv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-errChan:
+		return errors.Join(fmt.Errorf("server failed to listen on %s", httpSrv.Addr), err)
+	}
+
+	// Stop all jobs, ending their streaming responses before shutting down
+	s.mutex.Lock()
+	ids := make([]string, 0, len(s.jobs))
+	for id := range s.jobs {
+		ids = append(ids, id)
+	}
+	s.mutex.Unlock()
+	for _, id := range ids {
+		if _, _, err := s.removeJob(id); err != nil {
+			jobsConfig.Log.Errorf("Failed to stop job %s - %v", id, err)
+		}
+	}
+
+	return httpSrv.Shutdown(context.Background())
+}
+
+type jobResponse struct {
+	JobID     string    `json:"jobId"`
+	Submitted time.Time `json:"submitted"`
+
+	// One of starting, running, finished and failed
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	Repository string `json:"repository"`
+	GoodCommit string `json:"goodCommit"`
+	BadCommit  string `json:"badCommit"`
+}
+
+// newJobResponse returns the response describing the passed job. Requires the server's mutex to be held
+func newJobResponse(hosted *hostedJob) jobResponse {
+	res := jobResponse{
+		JobID:     hosted.id,
+		Submitted: hosted.submitted,
+
+		Repository: hosted.job.Repository,
+		GoodCommit: hosted.job.GoodCommit,
+		BadCommit:  hosted.job.BadCommit,
+	}
+
+	switch {
+	case hosted.err != nil:
+		res.Status = "failed"
+		res.Error = hosted.err.Error()
+	case hosted.server == nil:
+		res.Status = "starting"
+	default:
+		res.Status = replicasStatus(hosted.job.ReplicaStatuses())
+	}
+	return res
+}
+
+// replicasStatus returns the status of a started job with replicas of the passed statuses, which is finished once none of them is bisecting anymore.
+// Stopped replicas, e.g. cancelled ones, never find their offending commit, so they are done as well
+func replicasStatus(statuses []biscepter.ReplicaStatus) string {
+	for _, status := range statuses {
+		if !status.Finished && !status.Stopped {
+			return "running"
+		}
+	}
+	return "finished"
+}
+
+func (s *jobsServer) postJob(c *gin.Context) {
+	replicas := 1
+	if replicasParam := c.Query("replicas"); replicasParam != "" {
+		var err error
+		replicas, err = strconv.Atoi(replicasParam)
+		if err != nil || replicas < 1 {
+			c.AbortWithError(http.StatusBadRequest, fmt.Errorf("%s is not a valid amount of replicas", replicasParam))
+			return
+		}
+	}
+
+	job, err := biscepter.GetJobFromConfig(c.Request.Body)
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	if job.NetworkMode == "host" {
+		// Systems of concurrently hosted jobs would listen on the same ports of the host
+		c.AbortWithError(http.StatusBadRequest, fmt.Errorf("network mode host isn't supported for hosted jobs"))
+		return
+	}
+	// Every job keeps its files in its own directory, since jobs submitted with the same config would otherwise overwrite each other's state,
+	// and commits broken for one job aren't necessarily broken for another
+	dir, err := os.MkdirTemp("", "biscepter-job-")
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	job.ReplicasCount = replicas
+	job.Log = s.jobsConfig.Log
+	job.ImageCache = s.images
+	job.ReplicaSemaphore = s.semaphore
+	job.StateDirectory = dir
+	job.CommitReplacementsBackup = filepath.Join(dir, ".biscepter-replacements~")
+
+	hosted := &hostedJob{
+		id:        uniuri.New(),
+		submitted: time.Now(),
+
+		job: job,
+		dir: dir,
+	}
+	s.mutex.Lock()
+	s.jobs[hosted.id] = hosted
+	res := newJobResponse(hosted)
+	s.mutex.Unlock()
+
+	go s.startJob(hosted)
+
+	c.JSON(http.StatusAccepted, res)
+}
+
+// startJob runs the passed job and, once it started, serves its routes until it is stopped
+func (s *jobsServer) startJob(hosted *hostedJob) {
+	s.jobsConfig.Log.Infof("Starting job %s", hosted.id)
+	rsChan, ocChan, err := hosted.job.Run()
+
+	s.mutex.Lock()
+	if err != nil {
+		hosted.err = err
+		s.mutex.Unlock()
+		s.jobsConfig.Log.Errorf("Failed to start job %s - %v", hosted.id, err)
+		s.removeJobDirectory(hosted)
+		return
+	}
+
+	h := newHTTPServer(hosted.job, rsChan, ocChan, s.config.LeaseDuration)
+	hosted.server = h
+	deleted := hosted.deleted
+	s.mutex.Unlock()
+
+	if deleted {
+		// The job was deleted while starting, so it is no longer hosted
+		close(h.doneChan)
+		if err := hosted.job.Stop(); err != nil {
+			s.jobsConfig.Log.Errorf("Failed to stop job %s - %v", hosted.id, err)
+		}
+		s.removeJobDirectory(hosted)
+		return
+	}
+	s.jobsConfig.Log.Infof("Job %s is running", hosted.id)
+
+	// Remove the job once it is stopped via its stop route
+	select {
+	case <-h.exitChan:
+		if _, _, err := s.removeJob(hosted.id); err != nil {
+			s.jobsConfig.Log.Errorf("Failed to stop job %s - %v", hosted.id, err)
+		}
+	case <-h.doneChan:
+	}
+}
+
+// removeJob removes the job with the passed ID from the server and stops it, returning the summary of its results.
+// Returns false if there is no job with the passed ID.
+func (s *jobsServer) removeJob(id string) (biscepter.JobSummary, bool, error) {
+	s.mutex.Lock()
+	hosted, ok := s.jobs[id]
+	if !ok {
+		s.mutex.Unlock()
+		return biscepter.JobSummary{}, false, nil
+	}
+	delete(s.jobs, id)
+	h := hosted.server
+	if h == nil {
+		// The job is stopped once it started
+		hosted.deleted = true
+	}
+	s.mutex.Unlock()
+
+	if h == nil {
+		return biscepter.JobSummary{}, true, nil
+	}
+	s.jobsConfig.Log.Infof("Stopping job %s", id)
+	close(h.doneChan)
+	summary := hosted.job.Summary()
+	err := hosted.job.Stop()
+	s.removeJobDirectory(hosted)
+	return summary, true, err
+}
+
+// removeJobDirectory removes the directory holding the passed job's state and commit replacements, since job IDs are never reused
+func (s *jobsServer) removeJobDirectory(hosted *hostedJob) {
+	if err := os.RemoveAll(hosted.dir); err != nil {
+		s.jobsConfig.Log.Warnf("Failed to remove directory of job %s - %v", hosted.id, err)
+	}
+}
+
+func (s *jobsServer) getJobs(c *gin.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	res := make([]jobResponse, 0, len(s.jobs))
+	for _, hosted := range s.jobs {
+		res = append(res, newJobResponse(hosted))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *jobsServer) getJob(c *gin.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	hosted, ok := s.jobs[c.Param("id")]
+	if !ok {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	c.JSON(http.StatusOK, newJobResponse(hosted))
+}
+
+func (s *jobsServer) deleteJob(c *gin.Context) {
+	summary, found, err := s.removeJob(c.Param("id"))
+	switch {
+	case !found:
+		c.AbortWithStatus(http.StatusNotFound)
+	case err != nil:
+		c.AbortWithError(http.StatusInternalServerError, err)
+	default:
+		c.JSON(http.StatusOK, newSummaryResponse(summary))
+	}
+}
+
+// forJob returns a handler passing requests on to the server of the job with the ID passed in the path, using the passed handler
+func (s *jobsServer) forJob(handler func(*httpServer, *gin.Context)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		s.mutex.Lock()
+		hosted, ok := s.jobs[c.Param("id")]
+		var h *httpServer
+		var err error
+		if ok {
+			h, err = hosted.server, hosted.err
+		}
+		s.mutex.Unlock()
+
+		switch {
+		case !ok:
+			c.AbortWithStatus(http.StatusNotFound)
+		case err != nil:
+			c.AbortWithError(http.StatusConflict, fmt.Errorf("job failed to start - %w", err))
+		case h == nil:
+			c.AbortWithError(http.StatusConflict, fmt.Errorf("job is still starting"))
+		default:
+			handler(h, c)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CelineWuest/biscepter/pkg/biscepter"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJobsServer() (*jobsServer, *gin.Engine) {
+	s := &jobsServer{
+		jobs: make(map[string]*hostedJob),
+	}
+
+	router := gin.New()
+	router.POST("/jobs", s.postJob)
+	router.GET("/jobs", s.getJobs)
+	router.GET("/jobs/:id", s.getJob)
+	for _, route := range httpRoutes {
+		router.Handle(route.method, "/jobs/:id"+route.path, s.forJob(route.handler))
+	}
+	return s, router
+}
+
+func TestJobRoutes(t *testing.T) {
+	s, router := newTestJobsServer()
+	s.jobs["starting"] = &hostedJob{id: "starting", job: &biscepter.Job{}}
+	s.jobs["failed"] = &hostedJob{id: "failed", job: &biscepter.Job{}, err: errors.New("clone failed")}
+	running := newTestServer()
+	running.job = &biscepter.Job{}
+	s.jobs["running"] = &hostedJob{id: "running", job: running.job, server: running}
+
+	values := []struct {
+		path string
+
+		expectedCode int
+	}{
+		{"/jobs/unknown/replicas", http.StatusNotFound},
+		{"/jobs/starting/replicas", http.StatusConflict},
+		{"/jobs/failed/replicas", http.StatusConflict},
+		{"/jobs/running/replicas/0", http.StatusNotFound},
+		{"/jobs/running", http.StatusOK},
+		{"/jobs/unknown", http.StatusNotFound},
+	}
+
+	for _, v := range values {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, v.path, nil))
+		assert.Equalf(t, v.expectedCode, w.Code, "Wrong status code for %s", v.path)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "Listing jobs failed")
+	assert.Contains(t, w.Body.String(), `"status":"starting"`, "Starting job isn't listed as starting")
+	assert.Contains(t, w.Body.String(), `"error":"clone failed"`, "Failed job isn't listed with its error")
+}
+
+func TestPostJobInvalid(t *testing.T) {
+	s, router := newTestJobsServer()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader("repository: [")))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Invalid job config was accepted")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/jobs?replicas=0", strings.NewReader("")))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Invalid amount of replicas was accepted")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(`
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+dockerfile: "dockerfile"
+networkMode: "host"
+`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Job using the host's network was accepted")
+
+	assert.Empty(t, s.jobs, "Invalid job was added")
+}
+
+func TestHostedJobRequeue(t *testing.T) {
+	s, router := newTestJobsServer()
+	rsChan := make(chan biscepter.RunningSystem, 1)
+	h := newHTTPServer(&biscepter.Job{}, rsChan, make(chan biscepter.OffendingCommit), 0)
+	s.jobs["running"] = &hostedJob{id: "running", job: h.job, server: h}
+
+	// Hosted jobs requeue through their job, which requires systems handed out by it, so only check that it's set
+	assert.NotNil(t, h.requeue, "Hosted job's server has no requeue function")
+	var requeued []biscepter.RunningSystem
+	h.requeue = func(system biscepter.RunningSystem) {
+		requeued = append(requeued, system)
+	}
+
+	rsChan <- biscepter.RunningSystem{ReplicaIndex: 3}
+	router.ServeHTTP(failingWriter{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/jobs/running/system", nil))
+
+	assert.Equal(t, []biscepter.RunningSystem{{ReplicaIndex: 3}}, requeued, "Undelivered system of hosted job wasn't requeued")
+	assert.Empty(t, h.systems.systems, "Undelivered system of hosted job is still registered")
+}
+
+func TestRemoveJobDirectory(t *testing.T) {
+	s, _ := newTestJobsServer()
+	s.jobsConfig.Log = logrus.New()
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".biscepter-state-hash-0~"), nil, 0644), "Writing state file failed")
+	h := newHTTPServer(&biscepter.Job{StateDirectory: dir}, nil, nil, 0)
+	s.jobs["running"] = &hostedJob{id: "running", job: h.job, dir: dir, server: h}
+
+	_, ok, err := s.removeJob("running")
+	assert.True(t, ok, "Running job wasn't found")
+	assert.NoError(t, err, "Removing job failed")
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err), "Directory of removed job wasn't removed")
+}
+
+func TestReplicasStatus(t *testing.T) {
+	values := []struct {
+		statuses []biscepter.ReplicaStatus
+
+		expectedStatus string
+	}{
+		{[]biscepter.ReplicaStatus{{Finished: true}, {}}, "running"},
+		{[]biscepter.ReplicaStatus{{Finished: true}, {Finished: true}}, "finished"},
+		{[]biscepter.ReplicaStatus{{Finished: true}, {Stopped: true}}, "finished"},
+		{[]biscepter.ReplicaStatus{{Stopped: true}}, "finished"},
+	}
+
+	for i, v := range values {
+		assert.Equalf(t, v.expectedStatus, replicasStatus(v.statuses), "Wrong job status for test %d", i)
+	}
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/CelineWuest/biscepter/pkg/biscepter"
+	"github.com/dchest/uniuri"
+)
+
+var (
+	errSystemNotFound      = errors.New("running system not found")
+	errSystemExpired       = errors.New("lease of running system expired")
+	errConflictingVerdicts = errors.New("running system was already given a different verdict")
+)
+
+// A systemRegistry keeps track of the running systems handed out to clients, safe for concurrent use.
+// Handed out systems are leased for a limited time, after which they are released if they weren't rated.
+// Rated and expired systems are kept for a limited time as well, s.t. repeated and conflicting verdicts are still recognized, after which they are removed.
+type systemRegistry struct {
+	mutex   sync.Mutex
+	systems map[string]*registeredSystem
+
+	leaseDuration time.Duration // How long handed out systems are leased for, or 0 if they are leased forever
+	retention     time.Duration // How long systems are kept once they were rated or expired
+
+	release func(*biscepter.RunningSystem) // Called for systems whose lease expired
+}
+
+// A registeredSystem is a running system handed out to a client
+type registeredSystem struct {
+	system biscepter.RunningSystem
+
+	verdict string // The verdict the system was given, or empty if it wasn't rated yet
+	expired bool   // Whether the system's lease expired before it was rated
+
+	// The timer releasing the system once its lease expires, or nil if it is leased forever.
+	// Once the system was rated or expired, the timer removing it after the registry's retention
+	lease *time.Timer
+}
+
+// systemRetention is how long systems are kept by default once they were rated or expired
+const systemRetention = 10 * time.Minute
+
+func newSystemRegistry(leaseDuration time.Duration) *systemRegistry {
+	return &systemRegistry{
+		systems: make(map[string]*registeredSystem),
+
+		leaseDuration: leaseDuration,
+		retention:     systemRetention,
+
+		release: (*biscepter.RunningSystem).Release,
+	}
+}
+
+// register adds the passed running system to the registry, leasing it, and returns its ID
+func (r *systemRegistry) register(system biscepter.RunningSystem) string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	id := uniuri.New()
+	registered := &registeredSystem{system: system}
+	if r.leaseDuration != 0 {
+		registered.lease = time.AfterFunc(r.leaseDuration, func() { r.expire(id) })
+	}
+	r.systems[id] = registered
+	return id
+}
+
+// unregister removes the running system with the passed ID from the registry, e.g. because it couldn't be handed out
+func (r *systemRegistry) unregister(id string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if registered, ok := r.systems[id]; ok && registered.lease != nil {
+		registered.lease.Stop()
+	}
+	delete(r.systems, id)
+}
+
+// rate gives the running system with the passed ID the passed verdict, using the passed function to rate it.
+// Rating a system with the verdict it was already given is a no-op, whereas rating it with a different one returns errConflictingVerdicts.
+// The system is rated without holding the registry's lock, since rating may block until the system's replica is ready for its verdict.
+func (r *systemRegistry) rate(id string, verdict string, rate func(*biscepter.RunningSystem)) error {
+	r.mutex.Lock()
+	registered, ok := r.systems[id]
+	switch {
+	case !ok:
+		r.mutex.Unlock()
+		return errSystemNotFound
+	case registered.expired:
+		r.mutex.Unlock()
+		return errSystemExpired
+	case registered.verdict == verdict:
+		r.mutex.Unlock()
+		return nil
+	case registered.verdict != "":
+		r.mutex.Unlock()
+		return errConflictingVerdicts
+	}
+
+	if registered.lease != nil {
+		registered.lease.Stop()
+	}
+	registered.verdict = verdict
+	r.retain(id, registered)
+	system := registered.system
+	r.mutex.Unlock()
+
+	rate(&system)
+	return nil
+}
+
+// handedOut returns the running systems which were handed out and await a verdict, keyed by their ID
+func (r *systemRegistry) handedOut() map[string]biscepter.RunningSystem {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	systems := make(map[string]biscepter.RunningSystem)
+	for id, registered := range r.systems {
+		if registered.verdict == "" && !registered.expired {
+			systems[id] = registered.system
+		}
+	}
+	return systems
+}
+
+// renew extends the lease of the running system with the passed ID, s.t. it expires after the registry's lease duration from now on
+func (r *systemRegistry) renew(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	registered, ok := r.systems[id]
+	switch {
+	case !ok:
+		return errSystemNotFound
+	case registered.expired:
+		return errSystemExpired
+	}
+
+	if registered.lease != nil && registered.verdict == "" {
+		registered.lease.Reset(r.leaseDuration)
+	}
+	return nil
+}
+
+// expire releases the running system with the passed ID if it wasn't rated yet
+func (r *systemRegistry) expire(id string) {
+	r.mutex.Lock()
+	registered, ok := r.systems[id]
+	if !ok || registered.verdict != "" || registered.expired {
+		r.mutex.Unlock()
+		return
+	}
+
+	registered.expired = true
+	r.retain(id, registered)
+	system := registered.system
+	r.mutex.Unlock()
+
+	r.release(&system)
+}
+
+// retain removes the passed system, which was rated or expired, from the registry once the registry's retention passed.
+// The registry has to be locked while calling this
+func (r *systemRegistry) retain(id string, registered *registeredSystem) {
+	registered.lease = time.AfterFunc(r.retention, func() { r.unregister(id) })
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CelineWuest/biscepter/pkg/biscepter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemRegistryRate(t *testing.T) {
+	r := newSystemRegistry(0)
+	id := r.register(biscepter.RunningSystem{ReplicaIndex: 1})
+
+	rated := 0
+	rate := func(rs *biscepter.RunningSystem) {
+		assert.Equal(t, 1, rs.ReplicaIndex, "Wrong running system rated")
+		rated++
+	}
+
+	assert.NoError(t, r.rate(id, "good", rate), "Rating a running system failed")
+	assert.NoError(t, r.rate(id, "good", rate), "Repeating a verdict failed")
+	assert.Equal(t, 1, rated, "Repeating a verdict rated the running system again")
+	assert.ErrorIs(t, r.rate(id, "bad", rate), errConflictingVerdicts, "Conflicting verdict didn't raise the right error")
+	assert.ErrorIs(t, r.rate("unknown", "good", rate), errSystemNotFound, "Rating an unknown running system didn't raise the right error")
+
+	r.unregister(id)
+	assert.ErrorIs(t, r.rate(id, "good", rate), errSystemNotFound, "Rating an unregistered running system didn't raise the right error")
+}
+
+func TestSystemRegistryLease(t *testing.T) {
+	r := newSystemRegistry(50 * time.Millisecond)
+	released := make(chan int, 2)
+	r.release = func(rs *biscepter.RunningSystem) {
+		released <- rs.ReplicaIndex
+	}
+	rate := func(*biscepter.RunningSystem) {}
+
+	expiring := r.register(biscepter.RunningSystem{ReplicaIndex: 1})
+	rated := r.register(biscepter.RunningSystem{ReplicaIndex: 2})
+	assert.NoError(t, r.rate(rated, "bad", rate), "Rating a running system failed")
+
+	assert.Equal(t, 1, <-released, "Wrong running system released")
+	assert.ErrorIs(t, r.rate(expiring, "good", rate), errSystemExpired, "Rating an expired running system didn't raise the right error")
+	assert.ErrorIs(t, r.renew(expiring), errSystemExpired, "Renewing an expired running system didn't raise the right error")
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, released, 0, "Rated running system was released")
+
+	renewed := r.register(biscepter.RunningSystem{ReplicaIndex: 3})
+	for range 4 {
+		time.Sleep(25 * time.Millisecond)
+		assert.NoError(t, r.renew(renewed), "Renewing a running system failed")
+	}
+	assert.Len(t, released, 0, "Renewed running system was released")
+}
+
+func TestSystemRegistryRetention(t *testing.T) {
+	r := newSystemRegistry(50 * time.Millisecond)
+	r.retention = 50 * time.Millisecond
+	r.release = func(*biscepter.RunningSystem) {}
+	rate := func(*biscepter.RunningSystem) {}
+
+	expiring := r.register(biscepter.RunningSystem{ReplicaIndex: 1})
+	rated := r.register(biscepter.RunningSystem{ReplicaIndex: 2})
+	assert.NoError(t, r.rate(rated, "good", rate), "Rating a running system failed")
+	assert.NoError(t, r.rate(rated, "good", rate), "Repeating a verdict within the retention failed")
+
+	time.Sleep(200 * time.Millisecond)
+	assert.ErrorIs(t, r.rate(rated, "good", rate), errSystemNotFound, "Rated running system wasn't removed after the retention")
+	assert.ErrorIs(t, r.rate(expiring, "good", rate), errSystemNotFound, "Expired running system wasn't removed after the retention")
+	assert.Empty(t, r.handedOut(), "Removed running systems are still handed out")
+	assert.Empty(t, r.systems, "Registry still holds removed running systems")
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/CelineWuest/biscepter/pkg/biscepter"
 )
@@ -10,17 +12,48 @@ type ServerType int
 
 const (
 	HTTP ServerType = iota
+	GRPC
 )
 
+// ParseServerType returns the server type with the passed name, i.e. either "http" or "grpc"
+func ParseServerType(name string) (ServerType, error) {
+	switch strings.ToLower(name) {
+	case "http":
+		return HTTP, nil
+	case "grpc":
+		return GRPC, nil
+	}
+	return 0, fmt.Errorf("%s is not a valid server type", name)
+}
+
+// Config holds the settings of a server
+type Config struct {
+	Address string // The address on which the server listens. Defaults to localhost
+	Port    int    // The port on which the server listens
+
+	TLSCertFile string // The path to the PEM encoded certificate of the server, or empty if the server doesn't use TLS
+	TLSKeyFile  string // The path to the PEM encoded private key of the server's certificate. Required if TLSCertFile is set
+
+	Token        string // The bearer token clients have to authenticate with, or empty if no token is required
+	ClientCAFile string // The path to the PEM encoded CA certificates clients have to present a certificate of, or empty if no client certificate is required. Requires TLS
+
+	// How long running systems handed out to clients are leased for. Systems which weren't rated once their lease expires are released, s.t. their replica can continue.
+	// 0 if systems are leased forever
+	LeaseDuration time.Duration
+}
+
 type Server interface {
-	init(int, chan biscepter.RunningSystem, chan biscepter.OffendingCommit) error
+	init(Config, *biscepter.Job, chan biscepter.RunningSystem, chan biscepter.OffendingCommit) error
 }
 
-func NewServer(serverType ServerType, port int, rsChan chan biscepter.RunningSystem, ocChan chan biscepter.OffendingCommit) error {
+func NewServer(serverType ServerType, config Config, job *biscepter.Job, rsChan chan biscepter.RunningSystem, ocChan chan biscepter.OffendingCommit) error {
 	switch serverType {
 	case HTTP:
 		var server Server = &httpServer{}
-		return server.init(port, rsChan, ocChan)
+		return server.init(config, job, rsChan, ocChan)
+	case GRPC:
+		var server Server = &grpcServer{}
+		return server.init(config, job, rsChan, ocChan)
 	}
 	return fmt.Errorf("%d is not a valid server type", serverType)
 }
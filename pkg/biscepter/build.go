@@ -0,0 +1,201 @@
+package biscepter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/archive"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	"github.com/moby/patternmatcher/ignorefile"
+)
+
+// commitPlaceholder is replaced with the hash of the built commit in the values of build arguments
+const commitPlaceholder = "${COMMIT}"
+
+// A BuildSecret is a secret made available to the RUN --mount=type=secret instructions of the dockerfile, without being stored in the image.
+// Exactly one of File and Env has to be set
+type BuildSecret struct {
+	File string // The path to the file containing the secret
+	Env  string // The name of the environment variable containing the secret
+}
+
+// validate returns an error if the secret with the passed ID doesn't specify exactly one source
+func (s BuildSecret) validate(id string) error {
+	if (s.File == "") == (s.Env == "") {
+		return fmt.Errorf("build secret %s has to either specify a file or an environment variable", id)
+	}
+	return nil
+}
+
+type buildSecretYaml struct {
+	File string `yaml:"file"`
+	Env  string `yaml:"env"`
+}
+
+// buildOptionsString returns the build options affecting the built images in a deterministic format, or an empty string if none are set
+func (j *Job) buildOptionsString() string {
+	var options []string
+	if j.BuildTarget != "" {
+		options = append(options, "target="+j.BuildTarget)
+	}
+	if j.BuildPlatform != "" {
+		options = append(options, "platform="+j.BuildPlatform)
+	}
+	if j.ContextDir != "" {
+		options = append(options, "context="+j.ContextDir)
+	}
+	if j.IncludeGit {
+		options = append(options, "includeGit")
+	}
+	for _, pattern := range j.DockerIgnore {
+		options = append(options, "ignore="+pattern)
+	}
+	args := make([]string, 0, len(j.BuildArgs))
+	for key, value := range j.BuildArgs {
+		args = append(args, "arg="+key+"="+value)
+	}
+	slices.Sort(args)
+	return strings.Join(append(options, args...), "\n")
+}
+
+// getImageBuildOptions returns the options for building the passed image of the passed commit
+func (j *Job) getImageBuildOptions(commitHash string, imageName string) types.ImageBuildOptions {
+	buildArgs := make(map[string]*string, len(j.BuildArgs))
+	for key, value := range j.BuildArgs {
+		value = strings.ReplaceAll(value, commitPlaceholder, commitHash)
+		buildArgs[key] = &value
+	}
+
+	return types.ImageBuildOptions{
+		Tags:        []string{imageName},
+		ForceRemove: true,
+		Labels:      map[string]string{"biscepter": "1"},
+
+		BuildArgs: buildArgs,
+		Target:    j.BuildTarget,
+		Platform:  j.BuildPlatform,
+	}
+}
+
+// getBuildContext returns the tarred build context of the passed checkout of the repository, which is the job's ContextDir inside of it.
+// The passed dockerfile, relative to the context, is always part of the build context
+func (j *Job) getBuildContext(repoPath string, dockerfileName string) (io.ReadCloser, error) {
+	contextPath := filepath.Join(repoPath, j.ContextDir)
+	excludes, err := j.buildContextExcludes(contextPath, dockerfileName)
+	if err != nil {
+		return nil, err
+	}
+	return archive.TarWithOptions(contextPath, &archive.TarOptions{ExcludePatterns: excludes})
+}
+
+// buildContextExcludes returns the patterns of the files excluded from the build context at the passed path.
+// These are .git unless IncludeGit is set, the patterns of the context's .dockerignore and the job's DockerIgnore patterns
+func (j *Job) buildContextExcludes(contextPath string, dockerfileName string) ([]string, error) {
+	var excludes []string
+	if !j.IncludeGit {
+		excludes = append(excludes, ".git")
+	}
+
+	file, err := os.Open(filepath.Join(contextPath, ".dockerignore"))
+	if err == nil {
+		patterns, err := ignorefile.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("couldn't read .dockerignore of build context %s", contextPath), err)
+		}
+		excludes = append(excludes, patterns...)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Join(fmt.Errorf("couldn't open .dockerignore of build context %s", contextPath), err)
+	}
+	excludes = append(excludes, j.DockerIgnore...)
+
+	// Like the docker CLI, never exclude the files the daemon needs for the build
+	return append(excludes, "!"+filepath.ToSlash(dockerfileName), "!.dockerignore"), nil
+}
+
+// repositoryDockerfileInContext returns the path of the job's RepositoryDockerfile relative to the build context,
+// and whether the build context contains it
+func (j *Job) repositoryDockerfileInContext() (string, bool) {
+	dockerfileName, err := filepath.Rel(filepath.Clean(j.ContextDir), j.RepositoryDockerfile)
+	if err != nil || !filepath.IsLocal(dockerfileName) {
+		return "", false
+	}
+	return filepath.ToSlash(dockerfileName), true
+}
+
+// startBuildSession starts a BuildKit session providing the job's build secrets to builds of the passed docker client.
+// Returns the ID of the session and a function closing the session, which has to be called once the build is done
+func (j *Job) startBuildSession(apiClient *client.Client) (string, func(), error) {
+	sources := make([]secretsprovider.Source, 0, len(j.BuildSecrets))
+	for id, secret := range j.BuildSecrets {
+		if err := secret.validate(id); err != nil {
+			return "", nil, err
+		}
+		sources = append(sources, secretsprovider.Source{ID: id, FilePath: secret.File, Env: secret.Env})
+	}
+	store, err := secretsprovider.NewStore(sources)
+	if err != nil {
+		return "", nil, errors.Join(fmt.Errorf("couldn't load build secrets"), err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sess, err := session.NewSession(ctx, "biscepter", "")
+	if err != nil {
+		cancel()
+		return "", nil, errors.Join(fmt.Errorf("couldn't create build session"), err)
+	}
+	sess.Allow(secretsprovider.NewSecretProvider(store))
+
+	go func() {
+		err := sess.Run(ctx, func(ctx context.Context, proto string, meta map[string][]string) (net.Conn, error) {
+			return apiClient.DialHijack(ctx, "/session", proto, meta)
+		})
+		if err != nil {
+			j.Log.Warnf("Build session failed - %v", err)
+		}
+	}()
+
+	return sess.ID(), func() {
+		sess.Close()
+		cancel()
+	}, nil
+}
+
+// buildkitTraceOutput returns the output of the build steps contained in the passed aux value of a BuildKit trace message
+func buildkitTraceOutput(aux json.RawMessage) string {
+	var data []byte
+	if err := json.Unmarshal(aux, &data); err != nil {
+		return ""
+	}
+	var status controlapi.StatusResponse
+	if err := status.Unmarshal(data); err != nil {
+		return ""
+	}
+
+	var out strings.Builder
+	for _, vertex := range status.Vertexes {
+		if vertex.Started != nil && vertex.Completed == nil {
+			fmt.Fprintf(&out, "%s\n", vertex.Name)
+		}
+		if vertex.Error != "" {
+			fmt.Fprintf(&out, "%s: %s\n", vertex.Name, vertex.Error)
+		}
+	}
+	for _, log := range status.Logs {
+		out.Write(log.Msg)
+	}
+	return out.String()
+}
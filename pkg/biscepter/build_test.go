@@ -0,0 +1,128 @@
+package biscepter
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetImageBuildOptions(t *testing.T) {
+	job := Job{
+		BuildArgs: map[string]string{
+			"VERSION": "v-${COMMIT}",
+			"MODE":    "release",
+		},
+		BuildTarget:   "test",
+		BuildPlatform: "linux/arm64",
+	}
+
+	options := job.getImageBuildOptions("abc", "biscepter-abc:hash")
+	assert.Equal(t, []string{"biscepter-abc:hash"}, options.Tags, "Wrong image tags")
+	if assert.Len(t, options.BuildArgs, 2, "Wrong amount of build args") {
+		assert.Equal(t, "v-abc", *options.BuildArgs["VERSION"], "Commit wasn't substituted in build arg")
+		assert.Equal(t, "release", *options.BuildArgs["MODE"], "Wrong build arg")
+	}
+	assert.Equal(t, "test", options.Target, "Wrong build target")
+	assert.Equal(t, "linux/arm64", options.Platform, "Wrong build platform")
+}
+
+func TestImageHash(t *testing.T) {
+	hash := func(job *Job) string {
+		assert.NoError(t, job.parseDockerfile(), "parseDockerfile returned an error")
+		return job.imageHash
+	}
+
+	plain := hash(&Job{Dockerfile: "FROM scratch"})
+	assert.Equal(t, digest.FromString("FROM scratch").Encoded(), plain, "Image hash without build options isn't the dockerfile's hash")
+	withArgs := hash(&Job{Dockerfile: "FROM scratch", BuildArgs: map[string]string{"A": "1", "B": "2"}})
+	assert.NotEqual(t, plain, withArgs, "Build args didn't change the image hash")
+	for range 10 {
+		assert.Equal(t, withArgs, hash(&Job{Dockerfile: "FROM scratch", BuildArgs: map[string]string{"B": "2", "A": "1"}}), "Image hash isn't deterministic")
+	}
+	assert.NotEqual(t, withArgs, hash(&Job{Dockerfile: "FROM scratch", BuildArgs: map[string]string{"A": "1", "B": "3"}}), "Build arg values didn't change the image hash")
+	assert.NotEqual(t, plain, hash(&Job{Dockerfile: "FROM scratch", BuildTarget: "test"}), "Build target didn't change the image hash")
+	assert.NotEqual(t, plain, hash(&Job{Dockerfile: "FROM scratch", BuildPlatform: "linux/arm64"}), "Build platform didn't change the image hash")
+	assert.Equal(t, plain, hash(&Job{Dockerfile: "FROM scratch", BuildSecrets: map[string]BuildSecret{"a": {Env: "A"}}}), "Build secrets changed the image hash")
+	assert.NotEqual(t, plain, hash(&Job{Dockerfile: "FROM scratch", ContextDir: "app"}), "Context dir didn't change the image hash")
+	assert.NotEqual(t, plain, hash(&Job{Dockerfile: "FROM scratch", IncludeGit: true}), "Including .git didn't change the image hash")
+	assert.NotEqual(t, plain, hash(&Job{Dockerfile: "FROM scratch", DockerIgnore: []string{"*.md"}}), "Dockerignore patterns didn't change the image hash")
+}
+
+func TestBuildContextExcludes(t *testing.T) {
+	contextPath := t.TempDir()
+
+	job := Job{}
+	excludes, err := job.buildContextExcludes(contextPath, "Dockerfile")
+	assert.NoError(t, err, "buildContextExcludes without .dockerignore returned an error")
+	assert.Equal(t, []string{".git", "!Dockerfile", "!.dockerignore"}, excludes, "Wrong excludes without .dockerignore")
+
+	require.NoError(t, os.WriteFile(filepath.Join(contextPath, ".dockerignore"), []byte("# Comment\nnode_modules\n\n*.log\n"), 0644))
+	job = Job{IncludeGit: true, DockerIgnore: []string{"docs"}}
+	excludes, err = job.buildContextExcludes(contextPath, "build/Dockerfile")
+	assert.NoError(t, err, "buildContextExcludes returned an error")
+	assert.Equal(t, []string{"node_modules", "*.log", "docs", "!build/Dockerfile", "!.dockerignore"}, excludes, "Wrong excludes")
+}
+
+func TestGetBuildContext(t *testing.T) {
+	repo := t.TempDir()
+	for _, file := range []string{".git/HEAD", "README.md", "app/main.go", "app/app.log", "app/.dockerignore", "app/Dockerfile"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, file)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, file), []byte(file), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "app", ".dockerignore"), []byte("*.log\nDockerfile\n"), 0644))
+
+	files := func(job *Job) []string {
+		ctx, err := job.getBuildContext(repo, "Dockerfile")
+		require.NoError(t, err, "getBuildContext returned an error")
+		defer ctx.Close()
+
+		var files []string
+		reader := tar.NewReader(ctx)
+		for {
+			header, err := reader.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err, "Build context isn't a valid tar")
+			if header.Typeflag == tar.TypeReg {
+				files = append(files, header.Name)
+			}
+		}
+		slices.Sort(files)
+		return files
+	}
+
+	assert.Equal(t, []string{"README.md", "app/.dockerignore", "app/Dockerfile", "app/app.log", "app/main.go"}, files(&Job{}), "Wrong files in build context of repository")
+	assert.Equal(t, []string{".git/HEAD", "README.md", "app/.dockerignore", "app/Dockerfile", "app/app.log", "app/main.go"}, files(&Job{IncludeGit: true}), "Wrong files in build context including .git")
+	assert.Equal(t, []string{".dockerignore", "Dockerfile", "main.go"}, files(&Job{ContextDir: "app"}), "Wrong files in build context of context dir")
+	assert.Equal(t, []string{".dockerignore", "Dockerfile"}, files(&Job{ContextDir: "app", DockerIgnore: []string{"*.go"}}), "Wrong files in build context with dockerignore patterns")
+}
+
+func TestRepositoryDockerfileInContext(t *testing.T) {
+	tests := []struct {
+		contextDir           string
+		repositoryDockerfile string
+		dockerfileName       string
+		inContext            bool
+	}{
+		{"", "Dockerfile", "Dockerfile", true},
+		{"", "build/Dockerfile", "build/Dockerfile", true},
+		{"app", "app/Dockerfile", "Dockerfile", true},
+		{"app/", "app/build/Dockerfile", "build/Dockerfile", true},
+		{"app", "Dockerfile", "", false},
+		{"app", "build/Dockerfile", "", false},
+	}
+	for _, test := range tests {
+		job := Job{ContextDir: test.contextDir, RepositoryDockerfile: test.repositoryDockerfile}
+		dockerfileName, inContext := job.repositoryDockerfileInContext()
+		assert.Equal(t, test.inContext, inContext, "Wrong containment of %s in context %q", test.repositoryDockerfile, test.contextDir)
+		assert.Equal(t, test.dockerfileName, dockerfileName, "Wrong path of %s in context %q", test.repositoryDockerfile, test.contextDir)
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"slices"
 	"strings"
-	"sync"
 )
 
 // getCommitsBetween returns the hashes of all commits between the passed good and bad commit.
@@ -36,41 +36,73 @@ func getCommitsBetween(goodCommitHash, badCommitHash, repoPath string) ([]string
 	return append([]string{goodBoundaryCommit}, commits...), nil
 }
 
-// getActualCommit returns the hash of the commit which the passed commit results in, given the passed replacements
-func getActualCommit(commitHash string, commitReplacements *sync.Map) string {
-	if val, ok := commitReplacements.Load(commitHash); ok {
-		return getActualCommit(val.(string), commitReplacements)
+// getCommitGraph returns the hashes of all commits between the passed good and bad commit, along with the offsets of each commit's parents within the returned commits.
+// Unlike getCommitsBetween, not only the first parents but all ancestors of the bad commit which are not ancestors of the good commit are returned.
+// Parents which are not part of the returned commits are omitted.
+// The returned slice is ordered topologically, starting from the good commit at index 0 and the bad commit at the last index
+func getCommitGraph(goodCommitHash, badCommitHash, repoPath string) ([]string, [][]int, error) {
+	cmd := exec.Command("git", "rev-list", "--reverse", "--topo-order", "--parents", "^"+goodCommitHash, badCommitHash)
+	cmd.Dir = repoPath
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("failed to get rev-list of bad commit %s to good commit %s, output: %s", badCommitHash, goodCommitHash, out), err)
+	}
+	lines := []string{}
+	if len(out) != 0 {
+		lines = strings.Split(string(out[:len(out)-1]), "\n")
+	}
+
+	// Get excluded boundary commit
+	cmd = exec.Command("git", "rev-parse", goodCommitHash)
+	cmd.Dir = repoPath
+	out, err = cmd.CombinedOutput()
+	if err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("failed to get rev-list of bad commit %s to good commit %s, output: %s", badCommitHash, goodCommitHash, out), err)
 	}
-	return commitHash
+	goodBoundaryCommit := string(out)[:len(out)-1]
+
+	commits := []string{goodBoundaryCommit}
+	parents := [][]int{{}}
+	offsets := map[string]int{goodBoundaryCommit: 0}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		commitParents := []int{}
+		for _, parent := range fields[1:] {
+			if offset, ok := offsets[parent]; ok {
+				commitParents = append(commitParents, offset)
+			}
+		}
+		offsets[fields[0]] = len(commits)
+		commits = append(commits, fields[0])
+		parents = append(parents, commitParents)
+	}
+
+	return commits, parents, nil
 }
 
-// getMergedParent returns the commit hash of the current commit's parent which got merged, given the
-// passed parent is on the branch the parent got merged on.
-// If the current commit is not a merge commit or an octopus commit, getMergedParent returns an empty string
-func getMergedParent(curCommitHash, parentCommitHash, repoPath string) (string, error) {
+// getMergedParents returns the commit hashes of the current commit's parents which got merged, given the
+// passed parent is on the branch the parents got merged on.
+// For a regular merge commit, a single parent is returned, whereas for an octopus merge, all merged parents are returned in the order of the commit's parents.
+// If the current commit is not a merge commit, getMergedParents returns an empty slice
+func getMergedParents(curCommitHash, parentCommitHash, repoPath string) ([]string, error) {
 	cmd := exec.Command("git", "rev-parse", fmt.Sprintf("%s^@", curCommitHash))
 	cmd.Dir = repoPath
 	outBytes, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("couldn't check if commit is merge commit or not - %v, output: %s", err, outBytes)
+		return nil, fmt.Errorf("couldn't check if commit is merge commit or not - %v, output: %s", err, outBytes)
 	}
 	out := string(outBytes)
 	// Trim trailing newline
 	parents := strings.Split(out[:len(out)-1], "\n")
 	if len(parents) == 1 {
-		return "", nil
-	} else if len(parents) > 2 {
-		// Octopus commit
-		// TODO: Maybe implementable?
-		return "", nil
+		return []string{}, nil
 	}
 	// Merge commit!
 
-	if parentCommitHash == parents[0] {
-		return parents[1], nil
-	} else if parentCommitHash == parents[1] {
-		return parents[0], nil
+	parentIndex := slices.Index(parents, parentCommitHash)
+	if parentIndex == -1 {
+		return nil, fmt.Errorf("passed parent commit %s is not actually a parent of %s (%s)", parentCommitHash, curCommitHash, strings.Join(parents, ", "))
 	}
 
-	return "", fmt.Errorf("passed parent commit %s is not actually a parent of %s (%s or %s)", parentCommitHash, curCommitHash, parents[0], parents[1])
+	return slices.Delete(parents, parentIndex, parentIndex+1), nil
 }
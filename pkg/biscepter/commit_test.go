@@ -0,0 +1,70 @@
+package biscepter
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestRepo creates a git repository in a temporary directory by running the passed git commands in it
+func createTestRepo(t *testing.T, commands ...string) string {
+	dir := t.TempDir()
+	for _, command := range append([]string{"init -q -b main", "config user.name biscepter", "config user.email biscepter@example.com"}, commands...) {
+		cmd := exec.Command("git", strings.Fields(command)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoErrorf(t, err, "git %s failed, output: %s", command, out)
+	}
+	return dir
+}
+
+// revParse returns the hash of the passed revision in the repository at the passed path
+func revParse(t *testing.T, repoPath, rev string) string {
+	cmd := exec.Command("git", "rev-parse", rev)
+	cmd.Dir = repoPath
+	out, err := cmd.CombinedOutput()
+	require.NoErrorf(t, err, "git rev-parse %s failed, output: %s", rev, out)
+	return strings.TrimSpace(string(out))
+}
+
+func TestGetMergedParents(t *testing.T) {
+	repo := createTestRepo(t,
+		"commit -q --allow-empty -m base",
+		"branch a",
+		"branch b",
+		"branch c",
+		"checkout -q a",
+		"commit -q --allow-empty -m a",
+		"checkout -q b",
+		"commit -q --allow-empty -m b",
+		"checkout -q c",
+		"commit -q --allow-empty -m c",
+		"checkout -q main",
+		"commit -q --allow-empty -m main",
+		"merge -q --no-ff -m merge a",
+		"merge -q --no-ff -m octopus b c",
+	)
+
+	t.Run("Regular commit has no merged parents", func(t *testing.T) {
+		parents, err := getMergedParents(revParse(t, repo, "main~2"), revParse(t, repo, "main~3"), repo)
+		assert.NoError(t, err, "getMergedParents returned an error")
+		assert.Empty(t, parents, "Regular commit has merged parents")
+	})
+	t.Run("Merge commit has one merged parent", func(t *testing.T) {
+		parents, err := getMergedParents(revParse(t, repo, "main~1"), revParse(t, repo, "main~2"), repo)
+		assert.NoError(t, err, "getMergedParents returned an error")
+		assert.Equal(t, []string{revParse(t, repo, "a")}, parents, "Wrong merged parents of merge commit")
+	})
+	t.Run("Octopus merge commit has all merged parents", func(t *testing.T) {
+		parents, err := getMergedParents(revParse(t, repo, "main"), revParse(t, repo, "main~1"), repo)
+		assert.NoError(t, err, "getMergedParents returned an error")
+		assert.Equal(t, []string{revParse(t, repo, "b"), revParse(t, repo, "c")}, parents, "Wrong merged parents of octopus merge commit")
+	})
+	t.Run("Passed commit not being a parent errors", func(t *testing.T) {
+		_, err := getMergedParents(revParse(t, repo, "main"), revParse(t, repo, "a"), repo)
+		assert.Error(t, err, "Commit which is not a parent didn't raise an error")
+	})
+}
@@ -0,0 +1,86 @@
+package biscepter
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// getContainerConfigs returns the config and host config of the container of the passed service, running the passed image of the passed commit with the passed ports
+func (j *Job) getContainerConfigs(service Service, commitHash string, imageName string, exposedPorts nat.PortSet, portBindings nat.PortMap) (*container.Config, *container.HostConfig, error) {
+	env := make([]string, 0, len(service.Env))
+	for key, value := range service.Env {
+		env = append(env, key+"="+strings.ReplaceAll(value, commitPlaceholder, commitHash))
+	}
+	slices.Sort(env)
+
+	binds := make([]string, len(service.Volumes))
+	for i, volume := range service.Volumes {
+		bind, err := resolveVolume(volume)
+		if err != nil {
+			return nil, nil, err
+		}
+		binds[i] = bind
+	}
+
+	containerConfig := &container.Config{
+		Image:        imageName,
+		ExposedPorts: exposedPorts,
+		Labels:       map[string]string{"biscepter": "1"},
+
+		Env:        env,
+		Cmd:        service.Command,
+		Entrypoint: service.Entrypoint,
+	}
+
+	hostConfig := &container.HostConfig{
+		AutoRemove:   true,
+		PortBindings: portBindings,
+
+		Binds:       binds,
+		NetworkMode: container.NetworkMode(j.NetworkMode),
+		Resources: container.Resources{
+			NanoCPUs: int64(j.CPUs * 1e9),
+			Memory:   j.Memory,
+		},
+	}
+
+	return containerConfig, hostConfig, nil
+}
+
+// usesHostNetwork returns whether the job's containers use the network of the host, in which case their ports can't be mapped
+func (j *Job) usesHostNetwork() bool {
+	return container.NetworkMode(j.NetworkMode).IsHost()
+}
+
+// validateHostNetwork returns an error if the job uses the host's network with more than the passed amount of replicas.
+// Systems using the host's network listen on the same ports of the host, so at most one of them can run at once
+func (j *Job) validateHostNetwork(replicas int) error {
+	if j.usesHostNetwork() && replicas > 1 {
+		return fmt.Errorf("network mode host requires exactly one replica, since the systems of concurrent replicas would listen on the same ports, but the job has %d replicas", replicas)
+	}
+	return nil
+}
+
+// resolveVolume returns the passed volume in docker's source:target[:options] format, with a relative host path as source made absolute
+func resolveVolume(volume string) (string, error) {
+	source, rest, found := strings.Cut(volume, ":")
+	if !found || source == "" || rest == "" {
+		return "", fmt.Errorf("volume %s is not of the form source:target[:options]", volume)
+	}
+	// Sources not starting with a dot are either absolute paths or names of volumes
+	if source != "." && !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+		return volume, nil
+	}
+
+	source, err := filepath.Abs(source)
+	if err != nil {
+		return "", errors.Join(fmt.Errorf("couldn't resolve source of volume %s", volume), err)
+	}
+	return source + ":" + rest, nil
+}
@@ -0,0 +1,81 @@
+package biscepter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetContainerConfigs(t *testing.T) {
+	job := Job{
+		Env: map[string]string{
+			"REVISION": "rev-${COMMIT}",
+			"MODE":     "test",
+		},
+		Volumes:     []string{"fixtures:/data:ro", "/abs:/abs"},
+		Command:     []string{"serve", "--debug"},
+		Entrypoint:  []string{"/bin/app"},
+		CPUs:        1.5,
+		Memory:      512 * 1024 * 1024,
+		NetworkMode: "bridge",
+	}
+	exposedPorts := nat.PortSet{"80": {}}
+	portBindings := nat.PortMap{"80": {{HostIP: "127.0.0.1", HostPort: "1234"}}}
+
+	containerConfig, hostConfig, err := job.getContainerConfigs(job.services()[0], "abc", "biscepter-abc:hash", exposedPorts, portBindings)
+	assert.NoError(t, err, "getContainerConfigs returned an error")
+
+	assert.Equal(t, "biscepter-abc:hash", containerConfig.Image, "Wrong image")
+	assert.Equal(t, exposedPorts, containerConfig.ExposedPorts, "Wrong exposed ports")
+	assert.Equal(t, []string{"MODE=test", "REVISION=rev-abc"}, containerConfig.Env, "Wrong environment variables")
+	assert.Equal(t, []string{"serve", "--debug"}, []string(containerConfig.Cmd), "Wrong command")
+	assert.Equal(t, []string{"/bin/app"}, []string(containerConfig.Entrypoint), "Wrong entrypoint")
+
+	assert.True(t, hostConfig.AutoRemove, "Container isn't removed automatically")
+	assert.Equal(t, portBindings, hostConfig.PortBindings, "Wrong port bindings")
+	assert.Equal(t, []string{"fixtures:/data:ro", "/abs:/abs"}, hostConfig.Binds, "Wrong volumes")
+	assert.Equal(t, "bridge", string(hostConfig.NetworkMode), "Wrong network mode")
+	assert.Equal(t, int64(1_500_000_000), hostConfig.NanoCPUs, "Wrong CPU limit")
+	assert.Equal(t, int64(512*1024*1024), hostConfig.Memory, "Wrong memory limit")
+}
+
+func TestResolveVolume(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err, "Couldn't get working directory")
+
+	values := []struct {
+		volume   string
+		resolved string
+	}{
+		{"data:/data", "data:/data"},
+		{"/abs/path:/data:ro", "/abs/path:/data:ro"},
+		{"./fixtures:/data:ro", filepath.Join(wd, "fixtures") + ":/data:ro"},
+		{"../fixtures:/data", filepath.Join(filepath.Dir(wd), "fixtures") + ":/data"},
+		{".:/src", wd + ":/src"},
+	}
+	for _, v := range values {
+		resolved, err := resolveVolume(v.volume)
+		assert.NoError(t, err, "resolveVolume returned an error for %s", v.volume)
+		assert.Equal(t, v.resolved, resolved, "Wrongly resolved volume %s", v.volume)
+	}
+
+	for _, volume := range []string{"/data", ":/data", "data:"} {
+		_, err := resolveVolume(volume)
+		assert.Error(t, err, "Invalid volume %s didn't raise an error", volume)
+	}
+}
+
+func TestUsesHostNetwork(t *testing.T) {
+	assert.False(t, (&Job{}).usesHostNetwork(), "Default network mode uses host network")
+	assert.False(t, (&Job{NetworkMode: "bridge"}).usesHostNetwork(), "Bridge network mode uses host network")
+	assert.True(t, (&Job{NetworkMode: "host"}).usesHostNetwork(), "Host network mode doesn't use host network")
+}
+
+func TestValidateHostNetwork(t *testing.T) {
+	assert.NoError(t, (&Job{NetworkMode: "host"}).validateHostNetwork(1), "Host network mode with a single replica raised an error")
+	assert.NoError(t, (&Job{}).validateHostNetwork(3), "Default network mode with multiple replicas raised an error")
+	assert.Error(t, (&Job{NetworkMode: "host"}).validateHostNetwork(2), "Host network mode with multiple replicas didn't raise an error")
+}
@@ -0,0 +1,181 @@
+package biscepter
+
+import (
+	"math"
+	"slices"
+)
+
+// A commitGraph holds the ancestry of commits and tracks which of them could still be the offending commit.
+// It is used for bisecting with [DAGBisection].
+type commitGraph struct {
+	parents    [][]int // parents[i] holds the offsets of the parents of the commit at offset i. Parents outside of the graph are omitted
+	candidates []bool  // candidates[i] is true if the commit at offset i could still be the offending commit
+}
+
+// newCommitGraph creates a new commit graph from the passed parents, in which every commit but the one at offset 0 is a candidate
+func newCommitGraph(parents [][]int) *commitGraph {
+	candidates := make([]bool, len(parents))
+	for i := 1; i < len(candidates); i++ {
+		candidates[i] = true
+	}
+	return &commitGraph{
+		parents:    parents,
+		candidates: candidates,
+	}
+}
+
+// clone returns a copy of the commit graph whose candidates can be modified independently
+func (g *commitGraph) clone() *commitGraph {
+	return &commitGraph{
+		parents:    g.parents,
+		candidates: slices.Clone(g.candidates),
+	}
+}
+
+// ancestors returns which commits are ancestors of the commit at the passed offset, including the commit itself
+func (g *commitGraph) ancestors(commitOffset int) []bool {
+	ancestors := make([]bool, len(g.parents))
+	ancestors[commitOffset] = true
+	stack := []int{commitOffset}
+	for len(stack) != 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, parent := range g.parents[cur] {
+			if !ancestors[parent] {
+				ancestors[parent] = true
+				stack = append(stack, parent)
+			}
+		}
+	}
+	return ancestors
+}
+
+// markGood removes the commit at the passed offset and all its ancestors from the candidates
+func (g *commitGraph) markGood(commitOffset int) {
+	for i, isAncestor := range g.ancestors(commitOffset) {
+		if isAncestor {
+			g.candidates[i] = false
+		}
+	}
+}
+
+// markBad removes all commits which are not ancestors of the commit at the passed offset from the candidates
+func (g *commitGraph) markBad(commitOffset int) {
+	for i, isAncestor := range g.ancestors(commitOffset) {
+		g.candidates[i] = g.candidates[i] && isAncestor
+	}
+}
+
+// candidateCount returns the amount of commits which could still be the offending commit
+func (g *commitGraph) candidateCount() int {
+	count := 0
+	for _, isCandidate := range g.candidates {
+		if isCandidate {
+			count++
+		}
+	}
+	return count
+}
+
+// candidateAncestorCount returns how many candidates are ancestors of the commit at the passed offset, including the commit itself
+func (g *commitGraph) candidateAncestorCount(commitOffset int) int {
+	count := 0
+	for i, isAncestor := range g.ancestors(commitOffset) {
+		if isAncestor && g.candidates[i] {
+			count++
+		}
+	}
+	return count
+}
+
+// child returns the offset of the oldest child of the commit at the passed offset, or -1 if it has no children in the graph
+func (g *commitGraph) child(commitOffset int) int {
+	for i := commitOffset + 1; i < len(g.parents); i++ {
+		if slices.Contains(g.parents[i], commitOffset) {
+			return i
+		}
+	}
+	return -1
+}
+
+// expectedCandidatesLeft returns the expected amount of candidates left after testing a commit, given that
+// the tested commit has the passed amount of candidate ancestors and that every candidate is equally likely to be the offending commit
+func expectedCandidatesLeft(ancestorCount, candidateCount int) float64 {
+	a, n := float64(ancestorCount), float64(candidateCount)
+	return (a*a + (n-a)*(n-a)) / n
+}
+
+// getNextDAGCommit returns the next commit which should be used for bisection when bisecting with [DAGBisection].
+// This is the candidate which best halves the candidates, similarly to git bisect, unless testing a nearly as good cached build is cheaper.
+func (r replica) getNextDAGCommit() int {
+	candidateCount := r.graph.candidateCount()
+
+	best, bestCached := -1, -1
+	bestScore, bestCachedScore := -1, -1
+	ancestorCounts := make(map[int]int)
+	testable, cached := 0, 0
+	for i, isCandidate := range r.graph.candidates {
+		if !r.isTestableDAGCandidate(i, isCandidate) {
+			continue
+		}
+		testable++
+
+		ancestorCounts[i] = r.graph.candidateAncestorCount(i)
+		score := min(ancestorCounts[i], candidateCount-ancestorCounts[i])
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+		if r.parentJob.ImageCache.isBuilt(r.parentJob.getDockerImageOfCommit(r.commits[i])) {
+			cached++
+			if score > bestCachedScore {
+				bestCached, bestCachedScore = i, score
+			}
+		}
+	}
+
+	r.log.Infof("Expected amount of runs left: ~%.1f", math.Log2(float64(candidateCount)))
+
+	if bestCached == -1 || bestCached == best {
+		r.log.Debugf("Candidates %d, next commit %d splitting off %d candidates", candidateCount, best, bestScore)
+		return best
+	}
+
+	// Check, based on buildCost, whether testing the cached commit is worth it. See getNextCommit for the cost model used
+	expectedRuns := math.Log2(expectedCandidatesLeft(ancestorCounts[bestCached], candidateCount))
+	expectedRunsOld := math.Log2(expectedCandidatesLeft(ancestorCounts[best], candidateCount))
+
+	cachedFraction := float64(cached) / float64(testable)
+	uncachedFraction := 1.0 - cachedFraction
+
+	cachedCost := cachedFraction*expectedRuns + uncachedFraction*expectedRuns*r.parentJob.BuildCost + 1
+	uncachedCost := cachedFraction*expectedRunsOld + uncachedFraction*expectedRunsOld*r.parentJob.BuildCost + r.parentJob.BuildCost
+
+	r.log.Debugf("Candidates %d, best commit %d splitting off %d candidates, best cached commit %d splitting off %d candidates. Cached Fraction: %f, Cached Cost: %f, Uncached Cost: %f", candidateCount, best, bestScore, bestCached, bestCachedScore, cachedFraction, cachedCost, uncachedCost)
+
+	if uncachedCost < cachedCost {
+		r.log.Debugf("Would not save time by running cached commit %d", bestCached)
+		return best
+	}
+	r.log.Debugf("Time saved by running cached commit %d", bestCached)
+	return bestCached
+}
+
+// isTestableDAGCandidate returns whether the commit at the passed offset can be tested to narrow down the candidates.
+// This is the case for every candidate except for the oldest known bad commit and commits which break the build or were skipped.
+func (r replica) isTestableDAGCandidate(commitOffset int, isCandidate bool) bool {
+	if !isCandidate || commitOffset == r.badCommitOffset {
+		return false
+	}
+	_, isReplaced := r.getReplacement(r.commits[commitOffset])
+	return !isReplaced
+}
+
+// dagBisectionFinished returns whether there are no more commits left to test when bisecting with [DAGBisection]
+func (r replica) dagBisectionFinished() bool {
+	for i, isCandidate := range r.graph.candidates {
+		if r.isTestableDAGCandidate(i, isCandidate) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package biscepter
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// testGraphParents are the parents of the following commit graph, where 0 is the good and 5 the bad commit
+//
+//	0 --- 1 --- 3 --- 4 --- 5
+//	 \               /
+//	  ------ 2 ------
+var testGraphParents = [][]int{{}, {0}, {0}, {1}, {3, 2}, {4}}
+
+func TestCommitGraph(t *testing.T) {
+	t.Run("Marking a commit good removes its ancestors", func(t *testing.T) {
+		graph := newCommitGraph(testGraphParents)
+		graph.markGood(2)
+		assert.Equal(t, []bool{false, true, false, true, true, true}, graph.candidates, "Wrong candidates after marking commit good")
+		assert.Equal(t, 4, graph.candidateCount(), "Wrong candidate count after marking commit good")
+	})
+	t.Run("Marking a commit bad removes its non-ancestors", func(t *testing.T) {
+		graph := newCommitGraph(testGraphParents)
+		graph.markBad(3)
+		assert.Equal(t, []bool{false, true, false, true, false, false}, graph.candidates, "Wrong candidates after marking commit bad")
+		assert.Equal(t, 2, graph.candidateCount(), "Wrong candidate count after marking commit bad")
+	})
+	t.Run("Clones are independent", func(t *testing.T) {
+		graph := newCommitGraph(testGraphParents)
+		clone := graph.clone()
+		clone.markBad(1)
+		assert.Equal(t, 5, graph.candidateCount(), "Modifying a clone modified the original graph")
+	})
+	t.Run("Children are found", func(t *testing.T) {
+		graph := newCommitGraph(testGraphParents)
+		assert.Equal(t, 1, graph.child(0), "Wrong child of commit")
+		assert.Equal(t, 4, graph.child(2), "Wrong child of commit")
+		assert.Equal(t, -1, graph.child(5), "Commit without children has a child")
+	})
+}
+
+func TestGetNextDAGCommit(t *testing.T) {
+	values := []struct {
+		built     []string
+		buildCost float64
+
+		expectedIndex int
+	}{
+		{[]string{}, 1e10, 3},
+		{[]string{"c"}, 1e10, 3},
+		{[]string{"m"}, 1e10, 4},
+		{[]string{"m"}, 1, 3},
+		{[]string{"d"}, 1e10, 3},
+	}
+
+	for i, v := range values {
+		rep := replica{
+			badCommitOffset: 5,
+			commits:         []string{"good", "a", "b", "c", "m", "d"},
+			graph:           newCommitGraph(testGraphParents),
+			log:             logrus.NewEntry(logrus.StandardLogger()),
+			mutex:           &sync.Mutex{},
+			parentJob: &Job{
+				BuildCost:          v.buildCost,
+				ImageCache:         &ImageCache{built: make(map[string]bool)},
+				commitReplacements: &sync.Map{},
+			},
+		}
+		for _, image := range v.built {
+			rep.parentJob.ImageCache.built[rep.parentJob.getDockerImageOfCommit(image)] = true
+		}
+
+		assert.Equalf(t, v.expectedIndex, rep.getNextCommit(), "getNextCommit returned wrong offset for test %d; built: %v, buildCost: %f", i, v.built, v.buildCost)
+	}
+
+	t.Run("Broken commits are not tested", func(t *testing.T) {
+		rep := replica{
+			badCommitOffset: 5,
+			commits:         []string{"good", "a", "b", "c", "m", "d"},
+			graph:           newCommitGraph(testGraphParents),
+			log:             logrus.NewEntry(logrus.StandardLogger()),
+			mutex:           &sync.Mutex{},
+			parentJob: &Job{
+				ImageCache:         &ImageCache{built: make(map[string]bool)},
+				commitReplacements: &sync.Map{},
+			},
+		}
+		rep.parentJob.commitReplacements.Store("c", "m")
+
+		assert.NotEqual(t, 3, rep.getNextCommit(), "getNextCommit returned broken commit")
+
+		rep.applyVerdict(4, Bad)
+		rep.applyVerdict(1, Good)
+		rep.applyVerdict(2, Good)
+		assert.True(t, rep.dagBisectionFinished(), "Bisection not finished despite only broken commits being left")
+	})
+}
+
+func TestDAGCommitReplacement(t *testing.T) {
+	backup, err := os.CreateTemp(t.TempDir(), "replacements")
+	assert.NoError(t, err, "Couldn't create replacements backup")
+	defer backup.Close()
+
+	job := &Job{
+		commitReplacements:           &sync.Map{},
+		commitReplacementsBackupFile: backup,
+	}
+	// Replacement of linear bisection, e.g. persisted by a previous run, whose commit isn't part of the graph
+	job.commitReplacements.Store("a", "outside")
+
+	rep := &replica{
+		badCommitOffset: 5,
+		commits:         []string{"good", "a", "b", "c", "m", "d"},
+		graph:           newCommitGraph(testGraphParents),
+		log:             logrus.NewEntry(logrus.StandardLogger()),
+		parentJob:       job,
+		skippedCommits:  make(map[string]string),
+		mutex:           &sync.Mutex{},
+	}
+
+	assert.Equal(t, "c", rep.getActualCommit("a"), "Broken commit wasn't replaced with its child in the graph")
+	cur, next := rep.getCommitReplacement(1)
+	assert.Equal(t, "c", cur, "Wrong actual commit of replaced commit")
+	assert.Equal(t, "m", next, "Wrong replacement of replaced commit")
+
+	rep.replaceCommit(2)
+	assert.Equal(t, "m", rep.getActualCommit("b"), "Broken commit wasn't replaced with its child in the graph")
+	assert.True(t, job.isBrokenCommit("b"), "Broken commit wasn't shared with the job")
+	_, found := job.commitReplacements.Load("b")
+	assert.False(t, found, "Replacement of DAG bisection was stored in the job's replacements")
+	out, err := os.ReadFile(backup.Name())
+	assert.NoError(t, err, "Couldn't read replacements backup")
+	assert.Empty(t, out, "Replacement of DAG bisection was persisted")
+}
+
+func TestGetCommitGraph(t *testing.T) {
+	repo := createTestRepo(t,
+		"commit -q --allow-empty -m good",
+		"branch b",
+		"commit -q --allow-empty -m a",
+		"checkout -q b",
+		"commit -q --allow-empty -m b",
+		"checkout -q main",
+		"commit -q --allow-empty -m c",
+		"merge -q --no-ff -m m b",
+		"commit -q --allow-empty -m d",
+	)
+
+	commits, parents, err := getCommitGraph(revParse(t, repo, "main~4"), revParse(t, repo, "main"), repo)
+	assert.NoError(t, err, "getCommitGraph returned an error")
+	assert.Len(t, commits, 6, "Wrong amount of commits in graph")
+	assert.Equal(t, revParse(t, repo, "main~4"), commits[0], "Good commit is not the first commit")
+	assert.Equal(t, revParse(t, repo, "main"), commits[5], "Bad commit is not the last commit")
+	assert.Equal(t, []int{}, parents[0], "Good commit has parents")
+
+	for i, commit := range commits[1:] {
+		parentHashes := []string{}
+		for _, parent := range parents[i+1] {
+			parentHashes = append(parentHashes, commits[parent])
+		}
+		assert.Equalf(t, revParse(t, repo, commit+"^@"), strings.Join(parentHashes, "\n"), "Wrong parents of commit %s", commit)
+	}
+}
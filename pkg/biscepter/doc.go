@@ -4,11 +4,12 @@ Package biscepter provides a Go interface for creating and running bisection job
 Jobs can most easily be created by passing in a job config to [GetJobFromConfig], but can also be created manually by populating a [Job] struct.
 For a manually created job to work, at least the following fields have to be populated:
   - ReplicaCount
-  - GoodCommit & BadCommit
+  - GoodCommit & BadCommit, or a good and bad commit in every one of the Issues
   - Dockerfile or DockerfilePath
   - Repository
 
 Every replica represents one issue to be bisected, meaning that the ReplicaCount parameter symbolizes how many issues should be bisected concurrently.
+Using the Issues field, replicas can bisect between their own good and bad commits and be given additional commits known to be good, while still sharing the job's built images.
 
 After a job struct was acquired, the job can be started using [Job.Run].
 
@@ -0,0 +1,144 @@
+package biscepter
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType specifies what happened in an [Event]
+type EventType int
+
+const (
+	// A replica started building the image of a commit
+	EventImageBuildStarted EventType = iota
+	// A replica finished building the image of a commit
+	EventImageBuildFinished
+	// Building the image of a commit failed, so the commit is avoided from now on
+	EventImageBuildFailed
+	// A replica started a container running a commit
+	EventContainerStarted
+	// A healthcheck failed on a container running a commit, so the commit is avoided from now on
+	EventHealthcheckFailed
+	// A running system of a commit is ready to be tested
+	EventSystemReady
+	// A verdict was recorded for a commit. Only sent once the verdict is decided if the job has multiple trials per commit
+	EventVerdictRecorded
+	// A commit was reported to be broken and is replaced by another commit for all replicas
+	EventCommitReplaced
+	// A commit was skipped and is replaced by another commit for the skipping replica
+	EventCommitSkipped
+	// A replica found the offending commit of its issue
+	EventOffendingCommitFound
+	// An image build of a commit output a line
+	EventImageBuildOutput
+)
+
+// String returns the name of the event type in lower camel case
+func (t EventType) String() string {
+	switch t {
+	case EventImageBuildStarted:
+		return "imageBuildStarted"
+	case EventImageBuildFinished:
+		return "imageBuildFinished"
+	case EventImageBuildFailed:
+		return "imageBuildFailed"
+	case EventContainerStarted:
+		return "containerStarted"
+	case EventHealthcheckFailed:
+		return "healthcheckFailed"
+	case EventSystemReady:
+		return "systemReady"
+	case EventVerdictRecorded:
+		return "verdictRecorded"
+	case EventCommitReplaced:
+		return "commitReplaced"
+	case EventCommitSkipped:
+		return "commitSkipped"
+	case EventOffendingCommitFound:
+		return "offendingCommitFound"
+	case EventImageBuildOutput:
+		return "imageBuildOutput"
+	}
+	return fmt.Sprintf("eventType(%d)", int(t))
+}
+
+// An Event is something that happened while running a job, sent to every subscriber of the job's events
+type Event struct {
+	Type EventType // What happened
+
+	Time time.Time // When it happened
+
+	ReplicaIndex int    // The index of the replica the event stems from
+	Commit       string // The commit the event concerns
+
+	Replacement string  // The commit which replaces Commit. Only set for EventCommitReplaced and EventCommitSkipped
+	Verdict     Verdict // The recorded verdict. Only set for EventVerdictRecorded
+	Output      string  // The line output by the image build. Only set for EventImageBuildOutput
+}
+
+// eventBufferSize is the amount of events buffered for each subscriber. Events sent to subscribers whose buffer is full are dropped
+const eventBufferSize = 100
+
+// Subscribe returns a channel receiving every event of the job from now on, and a function to unsubscribe again.
+// The channel is closed once unsubscribed or once the job is stopped.
+// Subscribers which don't keep up with the job's events miss the events sent while their buffer is full.
+func (job *Job) Subscribe() (<-chan Event, func()) {
+	job.eventsMutex.Lock()
+	defer job.eventsMutex.Unlock()
+
+	if job.eventSubscribers == nil {
+		job.eventSubscribers = make(map[chan Event]struct{})
+	}
+	events := make(chan Event, eventBufferSize)
+	job.eventSubscribers[events] = struct{}{}
+
+	return events, func() {
+		job.eventsMutex.Lock()
+		defer job.eventsMutex.Unlock()
+
+		if _, ok := job.eventSubscribers[events]; ok {
+			delete(job.eventSubscribers, events)
+			close(events)
+		}
+	}
+}
+
+// publishEvent sends the passed event to every subscriber of the job's events, setting its time to now
+func (job *Job) publishEvent(event Event) {
+	job.eventsMutex.Lock()
+	defer job.eventsMutex.Unlock()
+
+	event.Time = time.Now()
+	for events := range job.eventSubscribers {
+		select {
+		case events <- event:
+		default:
+			if event.Type == EventImageBuildOutput {
+				// Build output is dropped silently, since builds output a lot of lines
+				continue
+			}
+			job.Log.Warnf("Dropping %s event for subscriber whose buffer is full", event.Type)
+		}
+	}
+}
+
+// closeSubscriptions unsubscribes every subscriber of the job's events, closing their channels
+func (job *Job) closeSubscriptions() {
+	job.eventsMutex.Lock()
+	defer job.eventsMutex.Unlock()
+
+	for events := range job.eventSubscribers {
+		close(events)
+	}
+	job.eventSubscribers = nil
+}
+
+// publishEvent sends an event of the passed type concerning the passed commit, stemming from this replica, to every subscriber of the job's events
+func (r *replica) publishEvent(eventType EventType, commitHash string) {
+	r.parentJob.publishEvent(Event{
+		Type: eventType,
+
+		ReplicaIndex: r.index,
+		Commit:       commitHash,
+	})
+}
@@ -0,0 +1,37 @@
+package biscepter
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	job := &Job{Log: logrus.StandardLogger()}
+
+	events, unsubscribe := job.Subscribe()
+	otherEvents, unsubscribeOther := job.Subscribe()
+
+	job.publishEvent(Event{Type: EventSystemReady, ReplicaIndex: 1, Commit: "a"})
+	event := <-events
+	assert.Equal(t, EventSystemReady, event.Type, "Wrong type of received event")
+	assert.Equal(t, "a", event.Commit, "Wrong commit of received event")
+	assert.False(t, event.Time.IsZero(), "Time of received event wasn't set")
+	assert.Equal(t, event, <-otherEvents, "Subscribers received different events")
+
+	unsubscribe()
+	_, ok := <-events
+	assert.False(t, ok, "Channel wasn't closed after unsubscribing")
+	unsubscribe()
+
+	// Events are dropped instead of blocking for subscribers which don't keep up
+	for i := 0; i < eventBufferSize+1; i++ {
+		job.publishEvent(Event{Type: EventVerdictRecorded})
+	}
+	assert.Len(t, otherEvents, eventBufferSize, "Wrong amount of buffered events")
+
+	job.closeSubscriptions()
+	unsubscribeOther()
+	assert.Len(t, otherEvents, eventBufferSize, "Buffered events were lost when closing subscriptions")
+}
@@ -11,8 +11,9 @@ import (
 )
 
 type healthcheckYaml struct {
-	Port int    `yaml:"port"`
-	Type string `yaml:"type"`
+	Port    int    `yaml:"port"`
+	Type    string `yaml:"type"`
+	Service string `yaml:"service"`
 
 	Data string `yaml:"data"`
 
@@ -40,7 +41,8 @@ const (
 	// Healthcheck consists of a single http GET request. Healthcheck data holds the path to which the request is sent
 	HttpGet200 HealthcheckType = iota
 	// Healthcheck consists of a custom script ran in bash. Healthcheck data holds the actual script.
-	// The environment variable `$PORT<XXXX>` can be used within the script to get the port to which `<XXXX>` was mapped to on the host (e.g. `$PORT443`)
+	// The environment variable `$PORT<XXXX>` can be used within the script to get the port to which `<XXXX>` was mapped to on the host (e.g. `$PORT443`).
+	// If the job has services, `$<SERVICE>_PORT<XXXX>` holds the port to which port `<XXXX>` of a service was mapped to (e.g. `$DB_PORT5432` for the service db)
 	Script
 )
 
@@ -48,21 +50,22 @@ const (
 type Healthcheck struct {
 	Port      int             // The port on which the healthcheck should be performed
 	CheckType HealthcheckType // The type of healthcheck to be performed
+	Service   string          // The service whose port is checked if the job has services. Defaults to the first service built from the bisected commit
 
 	Data   string            // Additional data for a given check type. Functionality depends on check type
 	Config HealthcheckConfig // The config for this healthcheck
 }
 
-// performHealthcheck performs the given healthcheck of the passed port mappings.
+// performHealthcheck performs the given healthcheck of the passed port mappings of the checked service, given the port mappings of all services.
 // If the healthcheck is unsuccessful, the returned boolean is false and the error may not be nil.
 // If the returned boolean is true, the returned error is nil
-func (h Healthcheck) performHealthcheck(portsMapping map[int]int, log *logrus.Entry) (bool, error) {
+func (h Healthcheck) performHealthcheck(portsMapping map[int]int, servicePorts map[string]map[int]int, log *logrus.Entry) (bool, error) {
 	var lastSuccess bool
 	var lastError error
 
 	backoffDuration := h.Config.Backoff
 	for i := 0; i < h.Config.Retries; i++ {
-		lastSuccess, lastError = h.performSingleHealthcheck(portsMapping)
+		lastSuccess, lastError = h.performSingleHealthcheck(portsMapping, servicePorts)
 
 		// Manage backoff
 		if (i != h.Config.Retries-1) && !lastSuccess {
@@ -89,7 +92,7 @@ func (h Healthcheck) performHealthcheck(portsMapping map[int]int, log *logrus.En
 // performHealthcheck performs a single try of the given healthcheck of the passed port mappings.
 // If the healthcheck is unsuccessful, the returned boolean is false and the error may not be nil.
 // If the returned boolean is true, the returned error is nil
-func (h Healthcheck) performSingleHealthcheck(portsMapping map[int]int) (bool, error) {
+func (h Healthcheck) performSingleHealthcheck(portsMapping map[int]int, servicePorts map[string]map[int]int) (bool, error) {
 	switch h.CheckType {
 	case HttpGet200:
 		res, err := http.Get(fmt.Sprintf("http://localhost:%d%s", portsMapping[h.Port], h.Data))
@@ -107,6 +110,7 @@ func (h Healthcheck) performSingleHealthcheck(portsMapping map[int]int) (bool, e
 		for k, v := range portsMapping {
 			cmd.Env = append(cmd.Env, fmt.Sprintf("PORT%d=%d", k, v))
 		}
+		cmd.Env = append(cmd.Env, servicePortsEnv(servicePorts)...)
 
 		if err := cmd.Run(); err != nil {
 			return false, fmt.Errorf("command didn't exit successfully, error: %v output: %s", err, out)
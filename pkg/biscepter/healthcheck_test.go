@@ -29,7 +29,7 @@ func TestPerformSingleHealthcheck(t *testing.T) {
 
 			ok, _ := check.performSingleHealthcheck(map[int]int{
 				1337: port,
-			})
+			}, nil)
 
 			assert.False(t, ok, "Unhealthy endpoint resulted in successful healthcheck")
 		})
@@ -50,7 +50,7 @@ func TestPerformSingleHealthcheck(t *testing.T) {
 
 			ok, err := check.performSingleHealthcheck(map[int]int{
 				1337: port,
-			})
+			}, nil)
 
 			assert.True(t, ok, "Healthy endpoint resulted in failed healthcheck")
 			assert.Nil(t, err, "Healthy endpoint resulted in an error being returned")
@@ -63,7 +63,7 @@ func TestPerformSingleHealthcheck(t *testing.T) {
 				Data:      "exit 1",
 			}
 
-			ok, _ := check.performSingleHealthcheck(map[int]int{})
+			ok, _ := check.performSingleHealthcheck(map[int]int{}, nil)
 
 			assert.False(t, ok, "Unhealthy endpoint resulted in successful healthcheck")
 		})
@@ -73,7 +73,7 @@ func TestPerformSingleHealthcheck(t *testing.T) {
 				Data:      "exit 0",
 			}
 
-			ok, _ := check.performSingleHealthcheck(map[int]int{})
+			ok, _ := check.performSingleHealthcheck(map[int]int{}, nil)
 
 			assert.True(t, ok, "Healthy endpoint resulted in failed healthcheck")
 		})
@@ -86,6 +86,23 @@ func TestPerformSingleHealthcheck(t *testing.T) {
 
 			ok, _ := check.performSingleHealthcheck(map[int]int{
 				1337: 42,
+			}, nil)
+
+			assert.True(t, ok, "Healthy endpoint resulted in unsuccessful healthcheck")
+		})
+		t.Run("Service port environment variables get substituted correctly", func(t *testing.T) {
+			check := Healthcheck{
+				Port:      1337,
+				CheckType: Script,
+				Service:   "app",
+				Data:      "if [ $PORT1337 -eq 42 ] && [ $DB_PORT5432 -eq 43 ]; then exit 0; fi; exit 1",
+			}
+
+			ok, _ := check.performSingleHealthcheck(map[int]int{
+				1337: 42,
+			}, map[string]map[int]int{
+				"app": {1337: 42},
+				"db":  {5432: 43},
 			})
 
 			assert.True(t, ok, "Healthy endpoint resulted in unsuccessful healthcheck")
@@ -0,0 +1,94 @@
+package biscepter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/sirupsen/logrus"
+)
+
+// An ImageCache keeps track of the docker images built by biscepter, safe for concurrent use.
+// Jobs sharing an image cache don't build the same image twice, even if they run at the same time.
+type ImageCache struct {
+	mutex  sync.RWMutex
+	built  map[string]bool // A hashmap where, if an image exists as a key, this image has already been built before
+	broken map[string]bool // A hashmap where, if an image exists as a key, building this image failed
+
+	building sync.Map // Map of locks for every image to ensure only one replica is building a specific image at once
+}
+
+// NewImageCache returns an image cache containing all images which were built by biscepter before
+func NewImageCache() (*ImageCache, error) {
+	cache := &ImageCache{
+		built:  make(map[string]bool),
+		broken: make(map[string]bool),
+	}
+
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create new docker client"), err)
+	}
+	defer cli.Close()
+	images, err := cli.ImageList(context.Background(), image.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.KeyValuePair{
+				Key:   "label",
+				Value: "biscepter=1",
+			},
+		),
+	})
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to list all docker images"), err)
+	}
+	for _, image := range images {
+		for _, tag := range image.RepoTags {
+			logrus.Debugf("Adding new built repo tag: %s", tag)
+			cache.built[tag] = true
+		}
+	}
+	return cache, nil
+}
+
+// isBuilt returns whether the image with the passed name was already built
+func (c *ImageCache) isBuilt(imageName string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.built[imageName]
+}
+
+// setBuilt marks the image with the passed name as built, s.t. it is not attempted to be built again
+func (c *ImageCache) setBuilt(imageName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.built[imageName] = true
+}
+
+// isBroken returns whether building the image with the passed name failed
+func (c *ImageCache) isBroken(imageName string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.broken[imageName]
+}
+
+// setBroken marks the image with the passed name as broken.
+// Broken images are marked as built as well, s.t. they are not attempted to be built again, neither by this job nor by other jobs sharing the cache.
+func (c *ImageCache) setBroken(imageName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.built[imageName] = true
+	c.broken[imageName] = true
+}
+
+// lockImage locks the image with the passed name, s.t. only one replica builds it at once, and returns a function unlocking it again
+func (c *ImageCache) lockImage(imageName string) func() {
+	l, _ := c.building.LoadOrStore(imageName, &sync.Mutex{})
+	lock := l.(*sync.Mutex)
+	lock.Lock()
+	return lock.Unlock
+}
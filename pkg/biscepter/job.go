@@ -8,6 +8,7 @@ import (
 	"math"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -15,9 +16,7 @@ import (
 	_ "crypto/sha1"
 
 	"github.com/creasty/defaults"
-	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/api/types/image"
-	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
 	"github.com/opencontainers/go-digest"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/semaphore"
@@ -30,6 +29,8 @@ type jobYaml struct {
 	GoodCommit string `yaml:"goodCommit"`
 	BadCommit  string `yaml:"badCommit"`
 
+	Issues []issueYaml `yaml:"issues"`
+
 	Host  string `yaml:"host"`
 	Port  int    `yaml:"port"`
 	Ports []int  `yaml:"ports"`
@@ -39,7 +40,47 @@ type jobYaml struct {
 	Dockerfile     string `yaml:"dockerfile"`
 	DockerfilePath string `yaml:"dockerfilePath"`
 
+	RepositoryDockerfile string `yaml:"repositoryDockerfile"`
+
+	ContextDir   string   `yaml:"contextDir"`
+	DockerIgnore []string `yaml:"dockerignore"`
+	IncludeGit   bool     `yaml:"includeGit"`
+
+	BuildArgs     map[string]string          `yaml:"buildArgs"`
+	BuildTarget   string                     `yaml:"buildTarget"`
+	BuildPlatform string                     `yaml:"buildPlatform"`
+	BuildSecrets  map[string]buildSecretYaml `yaml:"buildSecrets"`
+
+	Env         map[string]string `yaml:"env"`
+	Volumes     []string          `yaml:"volumes"`
+	Command     []string          `yaml:"command"`
+	Entrypoint  []string          `yaml:"entrypoint"`
+	CPUs        float64           `yaml:"cpus"`
+	Memory      string            `yaml:"memory"`
+	NetworkMode string            `yaml:"networkMode"`
+
+	Services []serviceYaml `yaml:"services"`
+
 	BuildCost float64 `yaml:"buildCost"`
+
+	BisectionMode string `yaml:"bisectionMode"`
+
+	FalseNegativeRate   float64 `yaml:"falseNegativeRate"`
+	ConfidenceThreshold float64 `yaml:"confidenceThreshold"`
+
+	Trials           int    `yaml:"trials"`
+	TrialAggregation string `yaml:"trialAggregation"`
+
+	MergeDescent         string `yaml:"mergeDescent"`
+	MaxMergeDescentDepth int    `yaml:"maxMergeDescentDepth"`
+
+	Test *testYaml `yaml:"test"`
+}
+
+type issueYaml struct {
+	GoodCommit  string   `yaml:"goodCommit"`
+	BadCommit   string   `yaml:"badCommit"`
+	GoodCommits []string `yaml:"goodCommits"`
 }
 
 // GetJobFromConfig reads in a job config in yaml format from a reader and initializes the corresponding job struct
@@ -64,15 +105,142 @@ func GetJobFromConfig(r io.Reader) (*Job, error) {
 		Dockerfile:     config.Dockerfile,
 		DockerfilePath: config.DockerfilePath,
 
+		RepositoryDockerfile: config.RepositoryDockerfile,
+
+		ContextDir:   config.ContextDir,
+		DockerIgnore: config.DockerIgnore,
+		IncludeGit:   config.IncludeGit,
+
+		BuildArgs:     config.BuildArgs,
+		BuildTarget:   config.BuildTarget,
+		BuildPlatform: config.BuildPlatform,
+
+		Env:         config.Env,
+		Volumes:     config.Volumes,
+		Command:     config.Command,
+		Entrypoint:  config.Entrypoint,
+		NetworkMode: config.NetworkMode,
+
 		Repository: config.Repository,
 	}
 
+	// Set the resource limits
+	if config.CPUs < 0 {
+		return nil, fmt.Errorf("negative amount of cpus supplied: %f", config.CPUs)
+	}
+	job.CPUs = config.CPUs
+	if config.Memory != "" {
+		memory, err := units.RAMInBytes(config.Memory)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("invalid memory limit supplied: %s", config.Memory), err)
+		}
+		job.Memory = memory
+	}
+
+	if job.RepositoryDockerfile != "" && !filepath.IsLocal(job.RepositoryDockerfile) {
+		return nil, fmt.Errorf("repository dockerfile %s is not a path inside the repository", job.RepositoryDockerfile)
+	}
+	if job.ContextDir != "" && !filepath.IsLocal(job.ContextDir) {
+		return nil, fmt.Errorf("context dir %s is not a path inside the repository", job.ContextDir)
+	}
+
+	for id, secret := range config.BuildSecrets {
+		buildSecret := BuildSecret{
+			File: secret.File,
+			Env:  secret.Env,
+		}
+		if err := buildSecret.validate(id); err != nil {
+			return nil, err
+		}
+		if job.BuildSecrets == nil {
+			job.BuildSecrets = make(map[string]BuildSecret)
+		}
+		job.BuildSecrets[id] = buildSecret
+	}
+
+	// Set the bisection mode
+	bisectionModes := map[string]BisectionMode{
+		"":              LinearBisection,
+		"linear":        LinearBisection,
+		"dag":           DAGBisection,
+		"probabilistic": ProbabilisticBisection,
+	}
+	bisectionMode, ok := bisectionModes[strings.ToLower(config.BisectionMode)]
+	if !ok {
+		return nil, fmt.Errorf("invalid bisection mode supplied: %s", config.BisectionMode)
+	}
+	job.BisectionMode = bisectionMode
+
+	if config.FalseNegativeRate < 0 || config.FalseNegativeRate >= 1 {
+		return nil, fmt.Errorf("false negative rate %f is not within [0, 1)", config.FalseNegativeRate)
+	}
+	if config.ConfidenceThreshold < 0 || config.ConfidenceThreshold > 1 {
+		return nil, fmt.Errorf("confidence threshold %f is not within [0, 1]", config.ConfidenceThreshold)
+	}
+	job.FalseNegativeRate = config.FalseNegativeRate
+	job.ConfidenceThreshold = config.ConfidenceThreshold
+
+	// Set the trials
+	if config.Trials < 0 {
+		return nil, fmt.Errorf("negative amount of trials supplied: %d", config.Trials)
+	}
+	job.Trials = config.Trials
+	trialAggregations := map[string]TrialAggregation{
+		"":         TrialAggregationAnyBad,
+		"any-bad":  TrialAggregationAnyBad,
+		"majority": TrialAggregationMajority,
+		"all-bad":  TrialAggregationAllBad,
+	}
+	trialAggregation, ok := trialAggregations[strings.ToLower(config.TrialAggregation)]
+	if !ok {
+		return nil, fmt.Errorf("invalid trial aggregation supplied: %s", config.TrialAggregation)
+	}
+	job.TrialAggregation = trialAggregation
+
+	// Set the merge descent policy
+	mergeDescentPolicies := map[string]MergeDescentPolicy{
+		"":          MergeDescentRecursive,
+		"recursive": MergeDescentRecursive,
+		"once":      MergeDescentOnce,
+		"off":       MergeDescentOff,
+	}
+	mergeDescent, ok := mergeDescentPolicies[strings.ToLower(config.MergeDescent)]
+	if !ok {
+		return nil, fmt.Errorf("invalid merge descent policy supplied: %s", config.MergeDescent)
+	}
+	job.MergeDescent = mergeDescent
+	if config.MaxMergeDescentDepth < 0 {
+		return nil, fmt.Errorf("negative max merge descent depth supplied: %d", config.MaxMergeDescentDepth)
+	}
+	job.MaxMergeDescentDepth = config.MaxMergeDescentDepth
+
+	for _, issue := range config.Issues {
+		job.Issues = append(job.Issues, Issue{
+			GoodCommit:  issue.GoodCommit,
+			BadCommit:   issue.BadCommit,
+			GoodCommits: issue.GoodCommits,
+		})
+	}
+
 	job.Ports = config.Ports
 	if config.Port != 0 {
 		job.Ports = []int{config.Port}
 	}
 
-	if len(job.Ports) == 0 {
+	for _, service := range config.Services {
+		job.Services = append(job.Services, Service{
+			Name:  service.Name,
+			Image: service.Image,
+
+			Ports:      service.Ports,
+			Env:        service.Env,
+			Volumes:    service.Volumes,
+			Command:    service.Command,
+			Entrypoint: service.Entrypoint,
+		})
+	}
+
+	if len(job.Ports) == 0 && len(job.Services) == 0 {
 		return nil, fmt.Errorf("no port specified for job")
 	}
 
@@ -97,6 +265,7 @@ func GetJobFromConfig(r io.Reader) (*Job, error) {
 		job.Healthchecks = append(job.Healthchecks, Healthcheck{
 			Port:      check.Port,
 			CheckType: checkType,
+			Service:   check.Service,
 
 			Data: check.Data,
 			Config: HealthcheckConfig{
@@ -110,60 +279,217 @@ func GetJobFromConfig(r io.Reader) (*Job, error) {
 		})
 	}
 
+	if err := job.validateServices(); err != nil {
+		return nil, err
+	}
+	if err := job.validateHostNetwork(len(job.Issues)); err != nil {
+		return nil, err
+	}
+
+	// Set the test
+	if config.Test != nil {
+		if err := defaults.Set(config.Test); err != nil {
+			return nil, err
+		}
+		if config.Test.Script == "" {
+			return nil, fmt.Errorf("no script specified for test")
+		}
+		badExitCode, brokenExitCode := *config.Test.BadExitCode, *config.Test.BrokenExitCode
+		if badExitCode == 0 {
+			return nil, fmt.Errorf("bad exit code of test script is 0, which is the exit code of good systems")
+		}
+		if brokenExitCode == 0 {
+			return nil, fmt.Errorf("broken exit code of test script is 0, which is the exit code of good systems")
+		}
+		if badExitCode == brokenExitCode {
+			return nil, fmt.Errorf("bad and broken exit codes of test script are both %d", badExitCode)
+		}
+
+		job.Test = &Test{
+			Script: config.Test.Script,
+
+			BadExitCode:    badExitCode,
+			BrokenExitCode: brokenExitCode,
+		}
+	}
+
 	return &job, nil
 }
 
+// BisectionMode specifies which commits replicas bisect and how they choose the next commit to test
+type BisectionMode int
+
+const (
+	// Bisect the first parents of the bad commit, continuing in merged branches according to the job's [MergeDescentPolicy]
+	LinearBisection BisectionMode = iota
+	// Bisect the full commit graph between the good and bad commit like git bisect does, always testing the commit which best halves the remaining commits.
+	// The job's [MergeDescentPolicy] is ignored, since merged branches are part of the bisected commits
+	DAGBisection
+	// Bisect the first parents of the bad commit like [LinearBisection], but for issues which don't always show up.
+	// Every verdict updates the probability of each commit being the offending commit, taking the job's [Job.FalseNegativeRate] into account.
+	// Commits may be tested multiple times, and the bisection finishes once one commit reaches the job's [Job.ConfidenceThreshold]
+	ProbabilisticBisection
+)
+
+// MergeDescentPolicy specifies whether replicas continue bisecting in the merged branch if the offending commit is a merge commit
+type MergeDescentPolicy int
+
+const (
+	// Descend into merged branches recursively, up to [Job.MaxMergeDescentDepth] nested merges deep
+	MergeDescentRecursive MergeDescentPolicy = iota
+	// Only descend into the merged branch of the first offending merge commit, but not into any nested merges
+	MergeDescentOnce
+	// Never descend into merged branches, reporting offending merge commits as is
+	MergeDescentOff
+)
+
+// An Issue holds the commits of the issue bisected by a single replica, allowing replicas of the same job to bisect between different commits.
+type Issue struct {
+	GoodCommit string // The hash of the good commit of this issue. Defaults to the job's good commit if empty
+	BadCommit  string // The hash of the bad commit of this issue. Defaults to the job's bad commit if empty
+
+	GoodCommits []string // The hashes of additional commits known to not exhibit this issue. Every commit which is an ancestor of one of these commits is considered good
+}
+
 // A job represents a blueprint for replicas, which are then used to bisect one issue.
 // Jobs can create multiple replicas at once.
 type Job struct {
-	ReplicasCount int // How many replicas of itself this job should spawn simultaneously. Each replica is to be used for bisecting one issue.
+	ReplicasCount int // How many replicas of itself this job should spawn simultaneously. Each replica is to be used for bisecting one issue. Raised to the amount of issues if lower.
+
+	Issues []Issue // The issues of the replicas, where Issues[i] is bisected by the replica with index i. Replicas without an issue bisect between the job's good and bad commit
 
 	// The cost multiplier of building a commit compared to running an already built commit.
 	// A build cost of 100 means building a commit is 100 times more expensive than running a built commit.
 	// A build cost of less than 1 results in biscepter always building the middle commit (if it was not built yet) and not using nearby, cached, builds.
 	BuildCost float64
 
+	BisectionMode BisectionMode // How the replicas bisect the commits between the good and bad commit. Defaults to [LinearBisection]
+
+	// The probability of a commit exhibiting the issue being rated good, e.g. because the issue didn't show up. Only used by [ProbabilisticBisection].
+	// Bad verdicts are always assumed to be correct
+	FalseNegativeRate float64
+	// The probability a commit has to reach for it to be reported as the offending commit. Only used by [ProbabilisticBisection]. Defaults to 0.95
+	ConfidenceThreshold float64
+
+	// How many times each commit is tested, each time in a fresh container, before its verdict is decided. Defaults to 1
+	Trials int
+	// How the verdicts of the trials of a commit are aggregated into the commit's verdict. Defaults to [TrialAggregationAnyBad]
+	TrialAggregation TrialAggregation
+
+	// Whether to continue bisecting in the merged branch if the offending commit is a merge commit. Defaults to [MergeDescentRecursive]
+	MergeDescent MergeDescentPolicy
+	// The max amount of nested merge commits to descend into when using [MergeDescentRecursive], or 0 if no limit
+	MaxMergeDescentDepth int
+
 	// The host to which the docker container ports should be exposed to. Defaults to 127.0.0.1.
 	// If you want the containers to be accessible from everywhere, set this to 0.0.0.0.
 	Host         string
 	Ports        []int         // The ports which this job needs
 	Healthchecks []Healthcheck // The healthchecks for this job
 
-	GoodCommit string // The hash of the good commit, i.e. the commit which does not exhibit any issues
-	BadCommit  string // The hash of the bad commit, i.e. the commit which exhibits the issue(s) to be bisected
+	Test *Test // The test used for automatically rating running systems using [RunningSystem.RunTest], or nil if there is none
+
+	GoodCommit string // The hash of the good commit, i.e. the commit which does not exhibit any issues. Used by all replicas whose issue doesn't specify its own
+	BadCommit  string // The hash of the bad commit, i.e. the commit which exhibits the issue(s) to be bisected. Used by all replicas whose issue doesn't specify its own
 
 	Dockerfile     string // The contents of the dockerfile.
 	DockerfilePath string // The path to the dockerfile relative to the present working directory. Only gets used if Dockerfile is empty.
+	// The path to a dockerfile inside the repository, relative to its root. Commits containing it are built using their own version of it,
+	// while other commits are built using Dockerfile or DockerfilePath. Commits are considered broken if neither is set
+	RepositoryDockerfile string
+
+	ContextDir   string   // The directory inside the repository, relative to its root, used as the build context. The repository's root if empty
+	DockerIgnore []string // Patterns of files excluded from the build context, in addition to the patterns of the context's .dockerignore
+	IncludeGit   bool     // Whether the .git directory is part of the build context. It is excluded by default, since it's rarely needed but slows down builds
+
+	// The build arguments passed to the dockerfile. Occurrences of ${COMMIT} in their values are replaced with the hash of the built commit
+	BuildArgs     map[string]string
+	BuildTarget   string // The stage of a multi-stage dockerfile to build, or empty to build the last stage
+	BuildPlatform string // The platform to build the images for, e.g. linux/arm64, or empty to build for the platform of the docker daemon
+	// The secrets available to RUN --mount=type=secret instructions of the dockerfile, keyed by their ID.
+	// Images are built using BuildKit if any secrets are set
+	BuildSecrets map[string]BuildSecret
+
+	// The environment variables set in the containers. Occurrences of ${COMMIT} in their values are replaced with the hash of the running commit
+	Env map[string]string
+	// The volumes mounted into the containers, in docker's source:target[:options] format.
+	// Sources starting with a dot are host paths relative to the present working directory
+	Volumes    []string
+	Command    []string // The command run by the containers instead of the image's CMD, or nil to keep it
+	Entrypoint []string // The entrypoint of the containers instead of the image's ENTRYPOINT, or nil to keep it
+	CPUs       float64  // The amount of CPUs available to each container, or 0 if no limit
+	Memory     int64    // The memory limit of each container in bytes, or 0 if no limit
+	// The network mode of the containers, e.g. host, or empty for docker's default bridge network.
+	// Containers using the host's network listen on the host's ports directly, s.t. their ports aren't mapped.
+	// Thus jobs using the host's network can only have a single replica
+	NetworkMode string
+
+	// The services making up each running system, started in order on a private network of the system, e.g. the app built from the bisected commit and a database.
+	// If set, Ports, Env, Volumes, Command, Entrypoint and NetworkMode have to be set per service instead.
+	// If empty, running systems consist of a single container of the image built from the bisected commit
+	Services []Service
 
 	Log *logrus.Logger // The log to which information gets printed to
 
-	MaxConcurrentReplicas uint // The max amount of replicas that can run concurrently, or 0 if no limit
-	replicaSemaphore      *semaphore.Weighted
+	MaxConcurrentReplicas uint // The max amount of replicas that can run concurrently, or 0 if no limit. Ignored if ReplicaSemaphore is set
+	// The semaphore limiting the amount of replicas running concurrently. Share it between jobs to limit the replicas of all of them.
+	// Created from MaxConcurrentReplicas if nil
+	ReplicaSemaphore *semaphore.Weighted
 
 	dockerfileString string // The parsed dockerfile for building the repository
-	dockerfileHash   string // The hash of the dockerfile string, for differentiating them in built images
+	imageHash        string // The hash of the dockerfile string and the build options, for differentiating them in built images
+
+	commitDockerfiles sync.Map // Map of commits to the commitDockerfile used for building them. Only used if RepositoryDockerfile is set
+
+	replicas      []*replica // This job's replicas
+	replicasMutex sync.Mutex // Mutex guarding replicas, for adding replicas while the job is running
+	// Mutex serializing the creation of replicas added while the job is running, s.t. replicasMutex doesn't have to be held while copying the repository
+	addReplicaMutex sync.Mutex
+
+	eventSubscribers map[chan Event]struct{} // The channels of the subscribers of this job's events
+	eventsMutex      sync.Mutex              // Mutex guarding eventSubscribers
 
-	replicas []*replica // This job's replicas
+	rsChan chan RunningSystem   // The channel to which the replicas send their running systems
+	ocChan chan OffendingCommit // The channel to which the replicas send their offending commits
 
 	Repository string // The repository URL
 	repoPath   string // The path to the original cloned repository which replicas will copy from
 
-	commits []string // This job's commits, where commits[0] is the good commit and commits[N-1] is the bad commit
+	commits     []string     // This job's commits, where commits[0] is the good commit and commits[N-1] is the bad commit
+	commitGraph *commitGraph // The ancestry of this job's commits if bisecting with DAGBisection, or nil otherwise
 
-	builtImages map[string]bool // A hashmap where, if a commit exists as a key, this commit's docker image has already been built before
+	issueCommits map[[2]string]bisectedCommits // The commits of issues whose good or bad commit differ from the job's, keyed by the issue's good and bad commit
 
-	imagesBuilding *sync.Map // Map of keys for every commit to ensure only one replica is building a specific commit at once
+	// The cache of docker images built before. Share it between jobs s.t. they don't build the same images twice.
+	// Created from the images present in docker if nil
+	ImageCache *ImageCache
+
+	builds buildTracker // The images being built by this job's replicas
 
 	commitReplacements *sync.Map // Map of commits to the commits they should be replaced with. used to avoid commits that break the build
+	// Set of commits found to break the build when bisecting with DAGBisection. Unlike commitReplacements, they aren't persisted,
+	// since each replica derives the commits they're replaced with from its own commit graph
+	brokenCommits sync.Map
 
 	// Path to the file where commit replacements are written to and stored for subsequent runs. Defaults to "$(PWD)/.biscepter-replacements~"
 	CommitReplacementsBackup     string
 	commitReplacementsBackupFile *os.File
+
+	// Path to the directory where the state files of the replicas are written to, used for resuming the job with [Job.Resume]. Defaults to "$(PWD)"
+	StateDirectory string
+	resume         bool // Whether the replicas should restore their state from their state files
+}
+
+// bisectedCommits holds the commits bisected between a good and a bad commit
+type bisectedCommits struct {
+	commits []string     // The commits, where commits[0] is the good commit and commits[N-1] is the bad commit
+	graph   *commitGraph // The ancestry of the commits if bisecting with DAGBisection, or nil otherwise
 }
 
 // Run the job. This initializes all the replicas and starts them. This function returns a [RunningSystem] channel and an [OffendingCommit] channel.
 // The [RunningSystem] channel should be used to get notified about systems which are ready to be tested.
 // Once an [OffendingCommit] was received for a given replica index, no more [RunningSystem] structs for this replica will appear in the [RunningSystem] channel.
+// Alternatively, the running systems and offending commit of a single replica can be received using [Job.NextSystem].
 func (job *Job) Run() (chan RunningSystem, chan OffendingCommit, error) {
 	// Init the logger
 	if job.Log == nil {
@@ -177,13 +503,14 @@ func (job *Job) Run() (chan RunningSystem, chan OffendingCommit, error) {
 	}
 
 	// Init the replica semaphore
-	if job.MaxConcurrentReplicas == 0 {
-		job.MaxConcurrentReplicas = math.MaxInt
+	if job.ReplicaSemaphore == nil {
+		if job.MaxConcurrentReplicas == 0 {
+			job.MaxConcurrentReplicas = math.MaxInt
+		}
+		job.ReplicaSemaphore = semaphore.NewWeighted(int64(job.MaxConcurrentReplicas))
 	}
-	job.replicaSemaphore = semaphore.NewWeighted(int64(job.MaxConcurrentReplicas))
 
 	// Init the sync maps
-	job.imagesBuilding = &sync.Map{}
 	job.commitReplacements = &sync.Map{}
 
 	// Read in the stored replacements
@@ -217,6 +544,25 @@ func (job *Job) Run() (chan RunningSystem, chan OffendingCommit, error) {
 		return nil, nil, err
 	}
 
+	if err := job.validateServices(); err != nil {
+		return nil, nil, err
+	}
+	if err := job.validateHostNetwork(max(job.ReplicasCount, len(job.Issues))); err != nil {
+		return nil, nil, err
+	}
+
+	if job.StateDirectory == "" {
+		job.StateDirectory = "."
+	}
+
+	if job.ConfidenceThreshold == 0 {
+		job.ConfidenceThreshold = 0.95
+	}
+
+	if job.Trials == 0 {
+		job.Trials = 1
+	}
+
 	job.Log.Info("Cloning initial repository...")
 	// Clone repo
 	job.repoPath, err = os.MkdirTemp("", "biscepter")
@@ -227,64 +573,39 @@ func (job *Job) Run() (chan RunningSystem, chan OffendingCommit, error) {
 		return nil, nil, errors.Join(fmt.Errorf("git clone of repository %s at %s failed, output: %s", job.Repository, job.repoPath, out), err)
 	}
 
-	job.Log.Info("Checking good and bad commits...")
-	// Make sure there is a path from BadCommit to GoodCommit
-	cmd := exec.Command("git", "rev-list", "--reverse", "--first-parent", job.BadCommit)
-	cmd.Dir = job.repoPath
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, nil, errors.Join(fmt.Errorf("failed to get rev-list of bad commit %s, output: %s", job.BadCommit, out), err)
-	}
-	if !strings.Contains(string(out), job.GoodCommit) {
-		return nil, nil, fmt.Errorf("good commit %s cannot be reached from bad commit %s", job.GoodCommit, job.BadCommit)
-	}
-
-	job.Log.Info("Getting all commits...")
-	// Get all commits
-	job.commits, err = getCommitsBetween(job.GoodCommit, job.BadCommit, job.repoPath)
-	if err != nil {
-		return nil, nil, fmt.Errorf("couldn't get commits between %s and %s - %v", job.GoodCommit, job.BadCommit, err)
+	// Get the job's commits, which replicas without an issue of their own bisect
+	if job.GoodCommit != "" || job.BadCommit != "" {
+		commits, err := job.getCommits(job.GoodCommit, job.BadCommit)
+		if err != nil {
+			return nil, nil, err
+		}
+		job.commits, job.commitGraph = commits.commits, commits.graph
 	}
+	job.issueCommits = make(map[[2]string]bisectedCommits)
 
-	job.Log.Info("Getting all built images...")
-	// Get all built images
-	job.builtImages = make(map[string]bool)
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
-	if err != nil {
-		return nil, nil, errors.Join(fmt.Errorf("failed to create new docker client"), err)
-	}
-	images, err := cli.ImageList(context.Background(), image.ListOptions{
-		All: true,
-		Filters: filters.NewArgs(
-			filters.KeyValuePair{
-				Key:   "label",
-				Value: "biscepter=1",
-			},
-		),
-	})
-	if err != nil {
-		return nil, nil, errors.Join(fmt.Errorf("failed to list all docker images"), err)
-	}
-	for _, image := range images {
-		for _, tag := range image.RepoTags {
-			logrus.Debugf("Adding new built repo tag: %s", tag)
-			job.builtImages[tag] = true
+	if job.ImageCache == nil {
+		job.Log.Info("Getting all built images...")
+		if job.ImageCache, err = NewImageCache(); err != nil {
+			return nil, nil, err
 		}
 	}
-	cli.Close()
 
 	job.Log.Info("Creating replicas...")
 	// Make the channels
 	// TODO: Don't hardcode channel size
 	rsChan, ocChan := make(chan RunningSystem, 100), make(chan OffendingCommit, 100)
+	job.rsChan, job.ocChan = rsChan, ocChan
 
+	if job.ReplicasCount < len(job.Issues) {
+		job.ReplicasCount = len(job.Issues)
+	}
 	job.replicas = make([]*replica, job.ReplicasCount)
 
 	// Create all replicas
 	for i := range job.ReplicasCount {
 		var err error
 		// Create a new replica
-		job.replicas[i], err = createJobReplica(job, i, fmt.Sprint(i))
+		job.replicas[i], err = job.newReplica(i, job.getIssue(i))
 		if err != nil {
 			// Stop running replicas
 			for j := range i {
@@ -310,8 +631,222 @@ func (job *Job) Run() (chan RunningSystem, chan OffendingCommit, error) {
 	return rsChan, ocChan, nil
 }
 
+// AddReplica creates a new replica bisecting the passed issue and starts it, returning the index of the new replica.
+// Empty good and bad commits of the issue default to the ones of the job.
+// The new replica's running systems and offending commit are sent to the channels returned by [Job.Run].
+//
+// This method errors if the passed job hasn't yet been initialized using [Job.Run], or if the job uses the host's network, which allows only a single replica.
+func (job *Job) AddReplica(issue Issue) (int, error) {
+	if job.rsChan == nil {
+		return 0, fmt.Errorf("job is not running. Have you initialized the passed job yet?")
+	}
+
+	job.addReplicaMutex.Lock()
+	defer job.addReplicaMutex.Unlock()
+
+	// Replicas are only appended while holding addReplicaMutex, s.t. the index stays free while the replica is created
+	job.replicasMutex.Lock()
+	index := len(job.replicas)
+	job.replicasMutex.Unlock()
+
+	if err := job.validateHostNetwork(index + 1); err != nil {
+		return 0, err
+	}
+
+	job.Log.Infof("Adding replica %d", index)
+	rep, err := job.newReplica(index, job.fillIssue(issue))
+	if err != nil {
+		return 0, errors.Join(fmt.Errorf("failed to create job replica %d", index), err)
+	}
+	if err := rep.start(job.rsChan, job.ocChan); err != nil {
+		return 0, errors.Join(fmt.Errorf("failed to start job replica %d", index), err, rep.stop())
+	}
+
+	job.replicasMutex.Lock()
+	defer job.replicasMutex.Unlock()
+	job.replicas = append(job.replicas, rep)
+	job.ReplicasCount = len(job.replicas)
+	return index, nil
+}
+
+// CancelReplica stops the replica with the passed index, abandoning the bisection of its issue without affecting any other replicas.
+// Running systems of the replica which were already sent out can no longer be rated, and no offending commit will be sent for the replica.
+//
+// This method errors if there is no replica with the passed index or if it was already stopped.
+func (job *Job) CancelReplica(index int) error {
+	job.replicasMutex.Lock()
+	if index < 0 || index >= len(job.replicas) {
+		job.replicasMutex.Unlock()
+		return fmt.Errorf("%w: no replica with index %d", ErrReplicaNotFound, index)
+	}
+	replica := job.replicas[index]
+	job.replicasMutex.Unlock()
+
+	// Cancelling waits for the replica's goroutine to exit, so don't block the job's other replicas meanwhile
+	return replica.cancel()
+}
+
+// NextSystem blocks until the replica with the passed index has a running system ready or has found its offending commit, and returns either of them.
+// Unlike the channels returned by [Job.Run], only the passed replica's running systems are returned, allowing the issues of different replicas to be tested concurrently.
+// Each running system is handed out only once, either by NextSystem or by the job's running system channel.
+// Running systems which become ready while no call of NextSystem waits for them are sent to the job's running system channel instead.
+// Once the replica has found its offending commit, every call returns it, regardless of whether it was already received from the job's offending commit channel.
+//
+// This method errors if the context is done first, if the replica was stopped, or if there is no replica with the passed index, in which case the error wraps [ErrReplicaNotFound].
+func (job *Job) NextSystem(ctx context.Context, replicaIndex int) (*RunningSystem, *OffendingCommit, error) {
+	job.replicasMutex.Lock()
+	if replicaIndex < 0 || replicaIndex >= len(job.replicas) {
+		job.replicasMutex.Unlock()
+		return nil, nil, fmt.Errorf("%w: no replica with index %d", ErrReplicaNotFound, replicaIndex)
+	}
+	replica := job.replicas[replicaIndex]
+	job.replicasMutex.Unlock()
+
+	select {
+	case rs := <-replica.systemChan:
+		return &rs, nil, nil
+	case <-replica.doneChan:
+		replica.mutex.Lock()
+		defer replica.mutex.Unlock()
+		if replica.offendingCommit == nil {
+			return nil, nil, fmt.Errorf("replica %d was stopped", replicaIndex)
+		}
+		oc := *replica.offendingCommit
+		return nil, &oc, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+// RequeueSystem hands out the passed running system, which was received but not yet rated, once again.
+// This is useful if the running system could not be passed on for testing, e.g. because the tester was gone.
+// The running system is passed to a waiting call of [Job.NextSystem] for the system's replica if there is one, and otherwise to whoever receives it first.
+func (job *Job) RequeueSystem(system RunningSystem) {
+	go system.parentReplica.offerSystem(system, job.rsChan)
+}
+
+// ErrReplicaNotFound is returned when a replica index is passed for which there is no replica
+var ErrReplicaNotFound = errors.New("replica not found")
+
+// getIssue returns the issue of the replica with the passed index, with the job's good and bad commits filled in where the issue doesn't specify them
+func (job *Job) getIssue(replicaIndex int) Issue {
+	var issue Issue
+	if replicaIndex < len(job.Issues) {
+		issue = job.Issues[replicaIndex]
+	}
+	return job.fillIssue(issue)
+}
+
+// fillIssue returns the passed issue with the job's good and bad commits filled in where the issue doesn't specify them
+func (job *Job) fillIssue(issue Issue) Issue {
+	if issue.GoodCommit == "" {
+		issue.GoodCommit = job.GoodCommit
+	}
+	if issue.BadCommit == "" {
+		issue.BadCommit = job.BadCommit
+	}
+	return issue
+}
+
+// getCommits checks that the passed bad commit is reachable from the passed good commit and returns all commits between them
+func (job *Job) getCommits(goodCommit, badCommit string) (bisectedCommits, error) {
+	if goodCommit == "" || badCommit == "" {
+		return bisectedCommits{}, fmt.Errorf("good commit %q or bad commit %q not specified", goodCommit, badCommit)
+	}
+
+	job.Log.Infof("Checking good commit %s and bad commit %s...", goodCommit, badCommit)
+	// Make sure there is a path from the bad commit to the good commit
+	if job.BisectionMode == DAGBisection {
+		cmd := exec.Command("git", "merge-base", "--is-ancestor", goodCommit, badCommit)
+		cmd.Dir = job.repoPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return bisectedCommits{}, errors.Join(fmt.Errorf("good commit %s is not an ancestor of bad commit %s, output: %s", goodCommit, badCommit, out), err)
+		}
+	} else {
+		cmd := exec.Command("git", "rev-list", "--reverse", "--first-parent", badCommit)
+		cmd.Dir = job.repoPath
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return bisectedCommits{}, errors.Join(fmt.Errorf("failed to get rev-list of bad commit %s, output: %s", badCommit, out), err)
+		}
+		if !strings.Contains(string(out), goodCommit) {
+			return bisectedCommits{}, fmt.Errorf("good commit %s cannot be reached from bad commit %s", goodCommit, badCommit)
+		}
+	}
+
+	job.Log.Infof("Getting all commits between %s and %s...", goodCommit, badCommit)
+	// Get all commits
+	var commits bisectedCommits
+	var err error
+	if job.BisectionMode == DAGBisection {
+		var parents [][]int
+		commits.commits, parents, err = getCommitGraph(goodCommit, badCommit, job.repoPath)
+		commits.graph = newCommitGraph(parents)
+	} else {
+		commits.commits, err = getCommitsBetween(goodCommit, badCommit, job.repoPath)
+	}
+	if err != nil {
+		return bisectedCommits{}, fmt.Errorf("couldn't get commits between %s and %s - %v", goodCommit, badCommit, err)
+	}
+	return commits, nil
+}
+
+// getIssueCommits returns the commits bisected for the passed issue, reusing the job's commits if the issue has the same good and bad commit
+func (job *Job) getIssueCommits(issue Issue) (bisectedCommits, error) {
+	if issue.GoodCommit == job.GoodCommit && issue.BadCommit == job.BadCommit {
+		return bisectedCommits{job.commits, job.commitGraph}, nil
+	}
+
+	key := [2]string{issue.GoodCommit, issue.BadCommit}
+	if commits, ok := job.issueCommits[key]; ok {
+		return commits, nil
+	}
+	commits, err := job.getCommits(issue.GoodCommit, issue.BadCommit)
+	if err != nil {
+		return bisectedCommits{}, err
+	}
+	job.issueCommits[key] = commits
+	return commits, nil
+}
+
+// newReplica creates a new replica with the passed index bisecting the passed issue.
+// The replica's known good commits are marked as good and its state is restored if the job is being resumed.
+func (job *Job) newReplica(index int, issue Issue) (*replica, error) {
+	commits, err := job.getIssueCommits(issue)
+	if err != nil {
+		return nil, err
+	}
+
+	rep, err := createJobReplica(job, index, fmt.Sprint(index), issue, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mark the known good commits of the issue
+	if err := rep.markGoodCommits(issue.GoodCommits); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to mark good commits of job replica %d", index), err, rep.stop())
+	}
+
+	// Open the replica's state file, restoring its progress if resuming
+	if err := rep.initState(job.resume); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to init state of job replica %d", index), err, rep.stop())
+	}
+
+	return rep, nil
+}
+
+// Resume runs the job just like [Job.Run], but restores the progress of every replica from the state file written by a previous run of the same job.
+// Replicas without a state file start bisecting from scratch.
+func (job *Job) Resume() (chan RunningSystem, chan OffendingCommit, error) {
+	job.resume = true
+	return job.Run()
+}
+
 // Stop the job and all running replicas.
 func (j *Job) Stop() error {
+	j.replicasMutex.Lock()
+	defer j.replicasMutex.Unlock()
+
 	for i, replica := range j.replicas {
 		j.Log.Infof("Shutting down replica %d", i)
 		if err := replica.stop(); err != nil {
@@ -319,6 +854,8 @@ func (j *Job) Stop() error {
 		}
 	}
 
+	j.closeSubscriptions()
+
 	return os.RemoveAll(j.repoPath)
 }
 
@@ -368,7 +905,7 @@ func (j *Job) RunCommitByOffset(commitOffset int) (*RunningCommit, error) {
 //
 // This method blocks until the running system is ready and has passed the healthchecks, or if something went wrong.
 func (j *Job) RunCommitByHash(commitHash string) (*RunningCommit, error) {
-	// Copy jobCopy and detach it from current job by reinitializing every pointer field except for imagesBuilding
+	// Copy jobCopy and detach it from current job by reinitializing every pointer field except for the image cache
 	jobCopy := &Job{
 		Log: j.Log,
 
@@ -380,13 +917,37 @@ func (j *Job) RunCommitByHash(commitHash string) (*RunningCommit, error) {
 		Host:         j.Host,
 		Ports:        j.Ports,
 		Healthchecks: j.Healthchecks,
+		Test:         j.Test,
 
 		Dockerfile:     j.Dockerfile,
 		DockerfilePath: j.DockerfilePath,
 
+		RepositoryDockerfile: j.RepositoryDockerfile,
+
+		ContextDir:   j.ContextDir,
+		DockerIgnore: j.DockerIgnore,
+		IncludeGit:   j.IncludeGit,
+
+		BuildArgs:     j.BuildArgs,
+		BuildTarget:   j.BuildTarget,
+		BuildPlatform: j.BuildPlatform,
+		BuildSecrets:  j.BuildSecrets,
+
+		Env:         j.Env,
+		Volumes:     j.Volumes,
+		Command:     j.Command,
+		Entrypoint:  j.Entrypoint,
+		CPUs:        j.CPUs,
+		Memory:      j.Memory,
+		NetworkMode: j.NetworkMode,
+
+		Services: j.Services,
+
 		// If the build breaks, we don't know the replacements, so just ignore
 		CommitReplacementsBackup: "/dev/null",
 
+		ImageCache: j.ImageCache,
+
 		GoodCommit: commitHash,
 		BadCommit:  commitHash,
 	}
@@ -398,7 +959,7 @@ func (j *Job) RunCommitByHash(commitHash string) (*RunningCommit, error) {
 	// Repeat commitHash thrice, s.t. the replica has to "bisect" it one single time
 	jobCopy.commits = []string{commitHash, commitHash, commitHash}
 
-	rep, err := createJobReplica(jobCopy, -1, commitHash)
+	rep, err := createJobReplica(jobCopy, -1, commitHash, Issue{GoodCommit: commitHash, BadCommit: commitHash}, bisectedCommits{commits: jobCopy.commits})
 	if err != nil {
 		return nil, err
 	}
@@ -425,21 +986,83 @@ func (j *Job) RunCommitByHash(commitHash string) (*RunningCommit, error) {
 
 // parseDockerfile sets j.dockerfileString based on the fields set.
 // It prioritizes Dockerfile but uses DockerfilePath if it is empty.
-// In addition, it sets dockerfileHash
+// If both are empty and RepositoryDockerfile is set, the job has no fallback dockerfile and j.dockerfileString stays empty.
+// In addition, it sets imageHash
 func (j *Job) parseDockerfile() error {
 	j.dockerfileString = j.Dockerfile
-	if j.dockerfileString == "" {
+	if j.dockerfileString == "" && (j.DockerfilePath != "" || j.RepositoryDockerfile == "") {
 		file, err := os.ReadFile(j.DockerfilePath)
 		if err != nil {
 			return err
 		}
 		j.dockerfileString = string(file)
 	}
-	j.dockerfileHash = digest.FromString(j.dockerfileString).Encoded()
+	j.imageHash = j.hashImage(j.dockerfileString)
 	return nil
 }
 
+// hashImage returns the hash of images built from the passed dockerfile with the job's build options
+func (j *Job) hashImage(dockerfile string) string {
+	// Build options are only hashed if set, s.t. images built without them can still be reused
+	if options := j.buildOptionsString(); options != "" {
+		dockerfile += "\n" + options
+	}
+	return digest.FromString(dockerfile).Encoded()
+}
+
+// A commitDockerfile is the dockerfile used for building a commit
+type commitDockerfile struct {
+	contents  string // The contents of the dockerfile, or empty if the commit can't be built
+	imageHash string // The hash of the image built using the dockerfile
+
+	inRepository bool // Whether the dockerfile is the commit's RepositoryDockerfile, instead of the job's dockerfile
+}
+
+// getDockerfileOfCommit returns the dockerfile used for building the passed commit.
+// This is the commit's RepositoryDockerfile if the job has one and the commit contains it, or the job's dockerfile otherwise
+func (j *Job) getDockerfileOfCommit(commit string) commitDockerfile {
+	fallback := commitDockerfile{contents: j.dockerfileString, imageHash: j.imageHash}
+	if j.RepositoryDockerfile == "" {
+		return fallback
+	}
+	if dockerfile, ok := j.commitDockerfiles.Load(commit); ok {
+		return dockerfile.(commitDockerfile)
+	}
+
+	dockerfile := fallback
+	cmd := exec.Command("git", "show", fmt.Sprintf("%s:%s", commit, j.RepositoryDockerfile))
+	cmd.Dir = j.repoPath
+	if out, err := cmd.Output(); err == nil {
+		dockerfile = commitDockerfile{contents: string(out), imageHash: j.hashImage(string(out)), inRepository: true}
+	} else {
+		j.Log.Debugf("Commit %s doesn't contain dockerfile %s, using the job's dockerfile - %v", commit, j.RepositoryDockerfile, err)
+	}
+	j.commitDockerfiles.Store(commit, dockerfile)
+	return dockerfile
+}
+
+// isBrokenCommit returns whether the passed commit is known to break the build
+func (j *Job) isBrokenCommit(commitHash string) bool {
+	if _, ok := j.commitReplacements.Load(commitHash); ok {
+		return true
+	}
+	_, ok := j.brokenCommits.Load(commitHash)
+	return ok
+}
+
+// allowsMergeDescent returns whether the job's merge descent policy allows descending into a merge commit,
+// given the amount of merge commits that were already descended into
+func (j *Job) allowsMergeDescent(depth int) bool {
+	switch j.MergeDescent {
+	case MergeDescentOff:
+		return false
+	case MergeDescentOnce:
+		return depth < 1
+	}
+	return j.MaxMergeDescentDepth == 0 || depth < j.MaxMergeDescentDepth
+}
+
 // getDockerImageOfCommit returns the name with the tag of the docker image which built the passed commit
 func (j *Job) getDockerImageOfCommit(commit string) string {
-	return fmt.Sprintf("biscepter-%s:%s", commit, j.dockerfileHash)
+	return fmt.Sprintf("biscepter-%s:%s", commit, j.getDockerfileOfCommit(commit).imageHash)
 }
@@ -1,10 +1,17 @@
 package biscepter
 
 import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetJobFromConfig(t *testing.T) {
@@ -35,6 +42,245 @@ dockerfile: "dockerfile"
 	assert.Equal(t, 1234, job.Healthchecks[0].Port, "Mismatch in job field")
 	assert.Equal(t, HttpGet200, job.Healthchecks[0].CheckType, "Mismatch in job field")
 	assert.Equal(t, "/status", job.Healthchecks[0].Data, "Mismatch in job field")
+	assert.Nil(t, job.Test, "Job without test section has a test")
+	assert.Equal(t, MergeDescentRecursive, job.MergeDescent, "Mismatch in job field")
+	assert.Equal(t, LinearBisection, job.BisectionMode, "Mismatch in job field")
+}
+
+func TestGetJobFromConfigBisectionMode(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+dockerfile: "dockerfile"
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml + "bisectionMode: dag\n"))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, DAGBisection, job.BisectionMode, "Mismatch in job field")
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "bisectionMode: sideways\n"))
+	assert.Error(t, err, "Invalid bisection mode didn't raise an error")
+
+	job, err = GetJobFromConfig(strings.NewReader(yml + "bisectionMode: probabilistic\nfalseNegativeRate: 0.2\nconfidenceThreshold: 0.9\n"))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, ProbabilisticBisection, job.BisectionMode, "Mismatch in job field")
+	assert.Equal(t, 0.2, job.FalseNegativeRate, "Mismatch in job field")
+	assert.Equal(t, 0.9, job.ConfidenceThreshold, "Mismatch in job field")
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "falseNegativeRate: 1\n"))
+	assert.Error(t, err, "Invalid false negative rate didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(yml + "confidenceThreshold: 1.5\n"))
+	assert.Error(t, err, "Invalid confidence threshold didn't raise an error")
+}
+
+func TestGetJobFromConfigTrials(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+dockerfile: "dockerfile"
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml + "trials: 3\ntrialAggregation: majority\n"))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, 3, job.Trials, "Mismatch in job field")
+	assert.Equal(t, TrialAggregationMajority, job.TrialAggregation, "Mismatch in job field")
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "trialAggregation: some-bad\n"))
+	assert.Error(t, err, "Invalid trial aggregation didn't raise an error")
+}
+
+func TestGetJobFromConfigMergeDescent(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+dockerfile: "dockerfile"
+maxMergeDescentDepth: 2
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml + "mergeDescent: Once\n"))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, MergeDescentOnce, job.MergeDescent, "Mismatch in job field")
+	assert.Equal(t, 2, job.MaxMergeDescentDepth, "Mismatch in job field")
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "mergeDescent: sometimes\n"))
+	assert.Error(t, err, "Invalid merge descent policy didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(strings.Replace(yml, "maxMergeDescentDepth: 2", "maxMergeDescentDepth: -1", 1)))
+	assert.Error(t, err, "Negative max merge descent depth didn't raise an error")
+}
+
+func TestAllowsMergeDescent(t *testing.T) {
+	values := []struct {
+		policy   MergeDescentPolicy
+		maxDepth int
+		depth    int
+
+		expected bool
+	}{
+		{MergeDescentRecursive, 0, 0, true},
+		{MergeDescentRecursive, 0, 42, true},
+		{MergeDescentRecursive, 2, 1, true},
+		{MergeDescentRecursive, 2, 2, false},
+		{MergeDescentOnce, 0, 0, true},
+		{MergeDescentOnce, 0, 1, false},
+		{MergeDescentOff, 0, 0, false},
+	}
+
+	for i, v := range values {
+		job := Job{
+			MergeDescent:         v.policy,
+			MaxMergeDescentDepth: v.maxDepth,
+		}
+		assert.Equalf(t, v.expected, job.allowsMergeDescent(v.depth), "allowsMergeDescent returned wrong result for test %d; policy: %d, max depth: %d, depth: %d", i, v.policy, v.maxDepth, v.depth)
+	}
+}
+
+func TestGetJobFromConfigTest(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+dockerfile: "dockerfile"
+test:
+  script: "exit 0"
+  brokenExitCode: 42
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+
+	if assert.NotNil(t, job.Test, "Job test not set") {
+		assert.Equal(t, "exit 0", job.Test.Script, "Mismatch in job test field")
+		assert.Equal(t, 1, job.Test.BadExitCode, "Mismatch in job test field")
+		assert.Equal(t, 42, job.Test.BrokenExitCode, "Mismatch in job test field")
+	}
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "  badExitCode: 42\n"))
+	assert.Error(t, err, "Identical bad and broken exit codes didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(yml + "  badExitCode: 0\n"))
+	assert.Error(t, err, "Bad exit code identical to the good exit code didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(strings.Replace(yml, "brokenExitCode: 42", "brokenExitCode: 0", 1)))
+	assert.Error(t, err, "Broken exit code identical to the good exit code didn't raise an error")
+}
+
+func TestGetJobFromConfigBuild(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+dockerfile: "dockerfile"
+buildArgs:
+  VERSION: "${COMMIT}"
+buildTarget: "test"
+buildPlatform: "linux/arm64"
+buildSecrets:
+  npmrc:
+    file: "/home/user/.npmrc"
+  token:
+    env: "NPM_TOKEN"
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, map[string]string{"VERSION": "${COMMIT}"}, job.BuildArgs, "Mismatch in job field")
+	assert.Equal(t, "test", job.BuildTarget, "Mismatch in job field")
+	assert.Equal(t, "linux/arm64", job.BuildPlatform, "Mismatch in job field")
+	assert.Equal(t, map[string]BuildSecret{
+		"npmrc": {File: "/home/user/.npmrc"},
+		"token": {Env: "NPM_TOKEN"},
+	}, job.BuildSecrets, "Mismatch in job field")
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "  both:\n    file: \"secret\"\n    env: \"SECRET\"\n"))
+	assert.Error(t, err, "Build secret with both a file and an environment variable didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(yml + "  none: {}\n"))
+	assert.Error(t, err, "Build secret without a source didn't raise an error")
+}
+
+func TestGetJobFromConfigContainer(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+dockerfile: "dockerfile"
+env:
+  REVISION: "${COMMIT}"
+volumes:
+  - "./fixtures:/data:ro"
+command: ["serve", "--debug"]
+entrypoint: ["/bin/app"]
+cpus: 1.5
+networkMode: "host"
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml + "memory: 512m\n"))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, map[string]string{"REVISION": "${COMMIT}"}, job.Env, "Mismatch in job field")
+	assert.Equal(t, []string{"./fixtures:/data:ro"}, job.Volumes, "Mismatch in job field")
+	assert.Equal(t, []string{"serve", "--debug"}, job.Command, "Mismatch in job field")
+	assert.Equal(t, []string{"/bin/app"}, job.Entrypoint, "Mismatch in job field")
+	assert.Equal(t, 1.5, job.CPUs, "Mismatch in job field")
+	assert.Equal(t, int64(512*1024*1024), job.Memory, "Mismatch in job field")
+	assert.Equal(t, "host", job.NetworkMode, "Mismatch in job field")
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "memory: lots\n"))
+	assert.Error(t, err, "Invalid memory limit didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(strings.Replace(yml, "cpus: 1.5", "cpus: -1", 1)))
+	assert.Error(t, err, "Negative amount of cpus didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(strings.Replace(yml, "./fixtures:/data:ro", "./fixtures", 1)))
+	assert.Error(t, err, "Malformed volume didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(yml + "issues:\n  - goodCommit: a\n  - goodCommit: b\n"))
+	assert.Error(t, err, "Host network mode with multiple replicas didn't raise an error")
+}
+
+func TestGetJobFromConfigServices(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+dockerfile: "dockerfile"
+services:
+  - name: "db"
+    image: "postgres:16"
+    ports: [5432]
+    env:
+      POSTGRES_PASSWORD: "test"
+  - name: "app"
+    ports: [3333]
+    env:
+      DATABASE_URL: "postgres://postgres:test@db:5432"
+healthcheck:
+  - port: 5432
+    service: "db"
+    type: script
+    data: "pg_isready -p $DB_PORT5432"
+  - port: 3333
+    type: http
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, []Service{
+		{Name: "db", Image: "postgres:16", Ports: []int{5432}, Env: map[string]string{"POSTGRES_PASSWORD": "test"}},
+		{Name: "app", Ports: []int{3333}, Env: map[string]string{"DATABASE_URL": "postgres://postgres:test@db:5432"}},
+	}, job.Services, "Mismatch in job field")
+	assert.Equal(t, "db", job.Healthchecks[0].Service, "Mismatch in job field")
+	assert.Equal(t, "", job.Healthchecks[1].Service, "Mismatch in job field")
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "port: 80\n"))
+	assert.Error(t, err, "Job port alongside services didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(strings.Replace(yml, `service: "db"`, `service: "redis"`, 1)))
+	assert.Error(t, err, "Healthcheck of unknown service didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(strings.Replace(yml, `image: "postgres:16"`, `image: "postgres:16"
+    volumes: [":/var/lib/postgresql/data"]`, 1)))
+	assert.Error(t, err, "Malformed volume of service didn't raise an error")
 }
 
 func TestGetDockerImageOfCommit(t *testing.T) {
@@ -50,9 +296,177 @@ func TestGetDockerImageOfCommit(t *testing.T) {
 
 	for _, v := range values {
 		job := Job{
-			dockerfileHash: v.hash,
+			imageHash: v.hash,
 		}
 
 		assert.Equal(t, v.image, job.getDockerImageOfCommit(v.commit), "Wrong docker image")
 	}
 }
+
+func TestGetDockerfileOfCommit(t *testing.T) {
+	repo := createTestRepo(t, "commit -q --allow-empty -m without-dockerfile")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "build"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "build", "Dockerfile"), []byte("FROM alpine"), 0644))
+	for _, command := range [][]string{{"add", "."}, {"commit", "-q", "-m", "with-dockerfile"}} {
+		cmd := exec.Command("git", command...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		require.NoErrorf(t, err, "git %v failed, output: %s", command, out)
+	}
+	withoutDockerfile, withDockerfile := revParse(t, repo, "main~1"), revParse(t, repo, "main")
+
+	job := &Job{
+		Dockerfile:           "FROM scratch",
+		RepositoryDockerfile: "build/Dockerfile",
+		Log:                  logrus.New(),
+		repoPath:             repo,
+	}
+	assert.NoError(t, job.parseDockerfile(), "parseDockerfile returned an error")
+
+	dockerfile := job.getDockerfileOfCommit(withDockerfile)
+	assert.True(t, dockerfile.inRepository, "Dockerfile of repository wasn't used")
+	assert.Equal(t, "FROM alpine", dockerfile.contents, "Wrong dockerfile of commit")
+	assert.Equal(t, job.hashImage("FROM alpine"), dockerfile.imageHash, "Image hash isn't derived from the commit's dockerfile")
+	assert.Equal(t, "biscepter-"+withDockerfile+":"+job.hashImage("FROM alpine"), job.getDockerImageOfCommit(withDockerfile), "Wrong docker image")
+
+	dockerfile = job.getDockerfileOfCommit(withoutDockerfile)
+	assert.False(t, dockerfile.inRepository, "Commit without dockerfile used dockerfile of repository")
+	assert.Equal(t, "FROM scratch", dockerfile.contents, "Commit without dockerfile didn't fall back to job's dockerfile")
+	assert.Equal(t, job.imageHash, dockerfile.imageHash, "Wrong image hash of fallback dockerfile")
+
+	// Without a fallback, commits lacking the dockerfile can't be built
+	job = &Job{
+		RepositoryDockerfile: "build/Dockerfile",
+		Log:                  logrus.New(),
+		repoPath:             repo,
+	}
+	assert.NoError(t, job.parseDockerfile(), "parseDockerfile without fallback dockerfile returned an error")
+	assert.Equal(t, "", job.getDockerfileOfCommit(withoutDockerfile).contents, "Commit without dockerfile has a dockerfile")
+	assert.Equal(t, "FROM alpine", job.getDockerfileOfCommit(withDockerfile).contents, "Wrong dockerfile of commit")
+}
+
+func TestGetJobFromConfigRepositoryDockerfile(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml + "repositoryDockerfile: build/Dockerfile\n"))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, "build/Dockerfile", job.RepositoryDockerfile, "Mismatch in job field")
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "repositoryDockerfile: ../Dockerfile\n"))
+	assert.Error(t, err, "Repository dockerfile outside of the repository didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(yml + "repositoryDockerfile: /Dockerfile\n"))
+	assert.Error(t, err, "Absolute repository dockerfile didn't raise an error")
+}
+
+func TestGetJobFromConfigBuildContext(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml + `
+contextDir: app
+dockerignore:
+  - "*.md"
+  - docs
+includeGit: true
+`))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, "app", job.ContextDir, "Mismatch in job field")
+	assert.Equal(t, []string{"*.md", "docs"}, job.DockerIgnore, "Mismatch in job field")
+	assert.True(t, job.IncludeGit, "Mismatch in job field")
+
+	job, err = GetJobFromConfig(strings.NewReader(yml))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.False(t, job.IncludeGit, ".git is included by default")
+
+	_, err = GetJobFromConfig(strings.NewReader(yml + "contextDir: ../app\n"))
+	assert.Error(t, err, "Context dir outside of the repository didn't raise an error")
+	_, err = GetJobFromConfig(strings.NewReader(yml + "contextDir: /app\n"))
+	assert.Error(t, err, "Absolute context dir didn't raise an error")
+}
+
+func TestGetJobFromConfigIssues(t *testing.T) {
+	yml := `
+repository: "repo"
+goodCommit: "goodCommit"
+badCommit: "badCommit"
+port: 80
+dockerfile: "dockerfile"
+issues:
+  - goodCommit: "issueGoodCommit"
+  - badCommit: "issueBadCommit"
+    goodCommits:
+      - "knownGoodCommit"
+`
+
+	job, err := GetJobFromConfig(strings.NewReader(yml))
+	assert.Nil(t, err, "GetJobFromConfig returned an error")
+	assert.Equal(t, []Issue{
+		{GoodCommit: "issueGoodCommit"},
+		{BadCommit: "issueBadCommit", GoodCommits: []string{"knownGoodCommit"}},
+	}, job.Issues, "Mismatch in job field")
+
+	assert.Equal(t, Issue{GoodCommit: "issueGoodCommit", BadCommit: "badCommit"}, job.getIssue(0), "Wrong issue of replica")
+	assert.Equal(t, Issue{GoodCommit: "goodCommit", BadCommit: "issueBadCommit", GoodCommits: []string{"knownGoodCommit"}}, job.getIssue(1), "Wrong issue of replica")
+	assert.Equal(t, Issue{GoodCommit: "goodCommit", BadCommit: "badCommit"}, job.getIssue(2), "Wrong issue of replica without issue")
+}
+
+func TestAddAndCancelReplica(t *testing.T) {
+	job := Job{}
+
+	_, err := job.AddReplica(Issue{})
+	assert.Error(t, err, "Adding a replica to a job which isn't running didn't raise an error")
+
+	err = job.CancelReplica(0)
+	assert.ErrorIs(t, err, ErrReplicaNotFound, "Cancelling a non-existent replica didn't raise the right error")
+}
+
+func TestNextSystem(t *testing.T) {
+	newReplica := func(index int) *replica {
+		return &replica{
+			index:      index,
+			mutex:      &sync.Mutex{},
+			systemChan: make(chan RunningSystem),
+			doneChan:   make(chan struct{}),
+		}
+	}
+	job := Job{rsChan: make(chan RunningSystem)}
+	job.replicas = []*replica{newReplica(0), newReplica(1)}
+	for _, r := range job.replicas {
+		r.parentJob = &job
+	}
+
+	// Systems of other replicas aren't returned
+	go job.replicas[0].offerSystem(RunningSystem{ReplicaIndex: 0}, job.rsChan)
+	go job.replicas[1].offerSystem(RunningSystem{ReplicaIndex: 1}, job.rsChan)
+	rs, oc, err := job.NextSystem(context.Background(), 1)
+	assert.Nil(t, err, "NextSystem returned an error")
+	assert.Nil(t, oc, "NextSystem returned an offending commit")
+	assert.Equal(t, 1, rs.ReplicaIndex, "NextSystem returned system of wrong replica")
+	assert.Equal(t, 0, (<-job.rsChan).ReplicaIndex, "Other replica's system wasn't handed out")
+
+	// The offending commit is returned once found
+	job.replicas[1].offendingCommit = &OffendingCommit{ReplicaIndex: 1, Commit: "c"}
+	close(job.replicas[1].doneChan)
+	for range 2 {
+		_, oc, err = job.NextSystem(context.Background(), 1)
+		assert.Nil(t, err, "NextSystem returned an error")
+		assert.Equal(t, "c", oc.Commit, "NextSystem returned wrong offending commit")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = job.NextSystem(ctx, 0)
+	assert.ErrorIs(t, err, context.Canceled, "NextSystem didn't return once the context was done")
+
+	_, _, err = job.NextSystem(context.Background(), 2)
+	assert.ErrorIs(t, err, ErrReplicaNotFound, "Getting the system of a non-existent replica didn't raise the right error")
+}
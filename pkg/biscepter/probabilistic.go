@@ -0,0 +1,82 @@
+package biscepter
+
+import "math"
+
+// newProbabilities returns the initial probabilities of each of the passed amount of commits being the offending commit when bisecting with [ProbabilisticBisection].
+// Every commit but the good one at offset 0 is equally likely to be the offending commit.
+func newProbabilities(commitCount int) []float64 {
+	probabilities := make([]float64, commitCount)
+	for i := 1; i < commitCount; i++ {
+		probabilities[i] = 1 / float64(commitCount-1)
+	}
+	return probabilities
+}
+
+// updateProbabilities updates the passed probabilities of each commit being the offending commit, given the passed verdict of the commit at the passed offset.
+// Bad verdicts are assumed to be reliable, whereas a commit exhibiting the issue is rated good with the passed false negative rate.
+// If the verdict contradicts the probabilities, i.e. all commits would become impossible to be the offending commit, the probabilities are left unchanged and false is returned.
+func updateProbabilities(probabilities []float64, commitOffset int, verdict Verdict, falseNegativeRate float64) bool {
+	updated := make([]float64, len(probabilities))
+	sum := 0.0
+	for i, p := range probabilities {
+		switch {
+		case verdict == Bad && i > commitOffset:
+			// The offending commit has to be an ancestor of a bad commit
+			p = 0
+		case verdict == Good && i <= commitOffset:
+			// If the offending commit is an ancestor of a good commit, the verdict was a false negative
+			p *= falseNegativeRate
+		}
+		updated[i] = p
+		sum += p
+	}
+
+	if sum == 0 {
+		return false
+	}
+	for i, p := range updated {
+		probabilities[i] = p / sum
+	}
+	return true
+}
+
+// mostProbableCommit returns the offset of the commit most likely to be the offending commit, along with its probability
+func mostProbableCommit(probabilities []float64) (int, float64) {
+	best := 0
+	for i, p := range probabilities {
+		if p > probabilities[best] {
+			best = i
+		}
+	}
+	return best, probabilities[best]
+}
+
+// medianCommit returns the offset of the commit strictly between the passed good and bad commit offsets
+// which best halves the probability mass, i.e. the commit for which the offending commit is as likely to be an ancestor as it is not to be.
+// Returns -1 if there are no commits between the good and bad commit.
+func medianCommit(probabilities []float64, goodCommitOffset, badCommitOffset int) int {
+	median := -1
+	bestDistance := math.Inf(1)
+	cumulative := 0.0
+	for i := 0; i < badCommitOffset; i++ {
+		cumulative += probabilities[i]
+		if i <= goodCommitOffset {
+			continue
+		}
+		if distance := math.Abs(cumulative - 0.5); distance < bestDistance {
+			median, bestDistance = i, distance
+		}
+	}
+	return median
+}
+
+// getNextProbabilisticCommit returns the next commit which should be used for bisection when bisecting with [ProbabilisticBisection].
+// This is the commit which best halves the probability mass, which may also be a commit that was already tested before.
+func (r replica) getNextProbabilisticCommit() int {
+	mostProbable, probability := mostProbableCommit(r.probabilities)
+	nextCommit := medianCommit(r.probabilities, r.goodCommitOffset, r.badCommitOffset)
+
+	r.log.Debugf("Good commit %d, Bad commit %d, next commit %d", r.goodCommitOffset, r.badCommitOffset, nextCommit)
+	r.log.Infof("Most probable offending commit %d with probability %.3f (threshold %.3f)", mostProbable, probability, r.parentJob.ConfidenceThreshold)
+	return nextCommit
+}
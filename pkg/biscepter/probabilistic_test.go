@@ -0,0 +1,82 @@
+package biscepter
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateProbabilities(t *testing.T) {
+	t.Run("Bad verdicts rule out all later commits", func(t *testing.T) {
+		probabilities := newProbabilities(5)
+		assert.True(t, updateProbabilities(probabilities, 2, Bad, 0.5), "Verdict was not applied")
+		assert.InDeltaSlice(t, []float64{0, 0.5, 0.5, 0, 0}, probabilities, 1e-9, "Wrong probabilities after bad verdict")
+	})
+	t.Run("Good verdicts make earlier commits less likely", func(t *testing.T) {
+		probabilities := newProbabilities(5)
+		assert.True(t, updateProbabilities(probabilities, 2, Good, 0.5), "Verdict was not applied")
+		assert.InDeltaSlice(t, []float64{0, 1.0 / 6, 1.0 / 6, 1.0 / 3, 1.0 / 3}, probabilities, 1e-9, "Wrong probabilities after good verdict")
+	})
+	t.Run("Contradicting verdicts are ignored", func(t *testing.T) {
+		probabilities := newProbabilities(5)
+		updateProbabilities(probabilities, 2, Bad, 0)
+		assert.False(t, updateProbabilities(probabilities, 2, Good, 0), "Contradicting verdict was applied")
+		assert.InDeltaSlice(t, []float64{0, 0.5, 0.5, 0, 0}, probabilities, 1e-9, "Contradicting verdict changed probabilities")
+	})
+}
+
+func TestMedianCommit(t *testing.T) {
+	values := []struct {
+		probabilities []float64
+		good, bad     int
+
+		expectedIndex int
+	}{
+		{[]float64{0, 0.25, 0.25, 0.25, 0.25}, 0, 4, 2},
+		{[]float64{0, 0.1, 0.1, 0.1, 0.7}, 0, 4, 3},
+		{[]float64{0, 0.7, 0.1, 0.1, 0.1}, 0, 4, 1},
+		{[]float64{0, 0, 0.5, 0.5, 0}, 1, 3, 2},
+		{[]float64{0, 0, 1}, 1, 2, -1},
+	}
+
+	for i, v := range values {
+		assert.Equalf(t, v.expectedIndex, medianCommit(v.probabilities, v.good, v.bad), "medianCommit returned wrong offset for test %d; probabilities: %v", i, v.probabilities)
+	}
+}
+
+func TestProbabilisticBisection(t *testing.T) {
+	// The offending commit is commit 3, which is falsely rated good on its first test
+	verdicts := map[int][]Verdict{3: {Good}}
+	rep := replica{
+		badCommitOffset: 7,
+		commits:         []string{"good", "a", "b", "c", "d", "e", "f", "bad"},
+		probabilities:   newProbabilities(8),
+		log:             logrus.NewEntry(logrus.StandardLogger()),
+		mutex:           &sync.Mutex{},
+		parentJob: &Job{
+			FalseNegativeRate:   0.2,
+			ConfidenceThreshold: 0.95,
+		},
+	}
+
+	for runs := 0; runs < 50; runs++ {
+		if offset, found := rep.foundCommitOffset(); found {
+			assert.Equal(t, 3, offset, "Wrong offending commit found")
+			assert.GreaterOrEqual(t, rep.probabilities[offset], 0.95, "Offending commit found below confidence threshold")
+			return
+		}
+
+		next := rep.getNextCommit()
+		verdict := Good
+		if next >= 3 {
+			verdict = Bad
+		}
+		if len(verdicts[next]) != 0 {
+			verdict, verdicts[next] = verdicts[next][0], verdicts[next][1:]
+		}
+		rep.applyVerdict(next, verdict)
+	}
+	assert.Fail(t, "Probabilistic bisection did not finish")
+}
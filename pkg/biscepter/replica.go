@@ -1,7 +1,9 @@
 package biscepter
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -9,17 +11,13 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"slices"
 	"strings"
 	"sync"
 
-	"github.com/dchest/uniuri"
 	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
-	"github.com/docker/docker/pkg/archive"
-	"github.com/docker/go-connections/nat"
 	"github.com/otiai10/copy"
-	"github.com/phayes/freeport"
 	"github.com/sirupsen/logrus"
 )
 
@@ -33,23 +31,57 @@ type replica struct {
 
 	repoPath string // The path to this replica's copy of the repo under test
 
+	issue Issue // The issue bisected by this replica
+
 	goodCommitOffset int // The offset to the original bad commit of the newest good commit
 	badCommitOffset  int // The offset to the original bad commit of the oldest bad commit
 
-	commits []string // This replica's commits, where commits[0] is the good commit and commits[N-1] is the bad commit
+	commits []string     // This replica's commits, where commits[0] is the good commit and commits[N-1] is the bad commit
+	graph   *commitGraph // The ancestry of this replica's commits if bisecting with DAGBisection, or nil otherwise
+
+	probabilities []float64 // probabilities[i] is the probability of the commit at offset i being the offending commit if bisecting with ProbabilisticBisection, or nil otherwise
 
 	waitingCond *sync.Cond // Condition variable used by goroutine created in replica.start to wait until the current commit was reported to be good or bad
 
-	isStopped bool // Whether this replica is running
+	ctx        context.Context    // Context cancelled once the replica is stopped, aborting whatever the goroutine created in replica.start is waiting for
+	cancelCtx  context.CancelFunc // Cancels ctx
+	exitedChan chan struct{}      // Channel closed once the goroutine created in replica.start exited, or nil if the replica wasn't started
+
+	isStopped       bool // Whether this replica is running
+	isFinished      bool // Whether this replica found the offending commit
+	awaitingVerdict bool // Whether the replica's last running system was sent out and awaits a verdict
+	// Mutex guarding isStopped, isFinished, awaitingVerdict, offendingCommit and skippedCommits, as well as writes to the replica's progress read by status snapshots,
+	// i.e. to its commits, their offsets, candidates and probabilities, and to its last running system
+	mutex *sync.Mutex
 
 	lastRunningSystem *RunningSystem // The last running system created by this replica. Is shut down when the replica is stopped
 
+	systemChan      chan RunningSystem // The channel through which Job.NextSystem receives this replica's running systems
+	doneChan        chan struct{}      // Channel closed once this replica found its offending commit or was stopped
+	offendingCommit *OffendingCommit   // The offending commit found by this replica, or nil if it wasn't found yet
+
 	log *logrus.Entry
 
 	possibleOtherCommits []string
+
+	skippedCommits map[string]string // Map of commits to the commits they should be replaced with, used to avoid commits which can't be tested for this replica's issue
+
+	trialVerdicts     []Verdict // The verdicts of the trials of the commit currently being tested, if its verdict is not yet decided
+	trialCommitOffset int       // The offset of the commit currently being tested in multiple trials
+
+	mergeChain []string // The merge commits which were descended into, starting with the outermost one
+
+	octopusMerge          string   // The octopus merge whose merged branches are currently being bisected, or empty if there is none
+	octopusBase           string   // The parent of the octopus merge on the branch the other parents got merged on
+	octopusBranches       []string // The tips of the branches merged by the octopus merge
+	octopusBranchIndex    int      // The index of the octopus merge's branch that is currently being bisected
+	octopusOffendingTip   string   // The tip of the octopus merge's branch which introduced the issue, or empty if it is not yet known
+	octopusOffendingMerge string   // The octopus merge whose merged branch introduced the issue, or empty if it is not yet known
+
+	stateFile *os.File // The file to which every verdict is written for resuming the bisection later on, or nil if the state is not persisted
 }
 
-func createJobReplica(j *Job, index int, id string) (*replica, error) {
+func createJobReplica(j *Job, index int, id string, issue Issue, commits bisectedCommits) (*replica, error) {
 	// Copy the repo
 	dir, err := os.MkdirTemp("", "biscepter")
 	if err != nil {
@@ -62,6 +94,17 @@ func createJobReplica(j *Job, index int, id string) (*replica, error) {
 		return nil, err
 	}
 
+	var graph *commitGraph
+	if commits.graph != nil {
+		graph = commits.graph.clone()
+	}
+	var probabilities []float64
+	if j.BisectionMode == ProbabilisticBisection {
+		probabilities = newProbabilities(len(commits.commits))
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
 	return &replica{
 		parentJob: j,
 
@@ -70,12 +113,26 @@ func createJobReplica(j *Job, index int, id string) (*replica, error) {
 
 		repoPath: dir,
 
+		issue: issue,
+
 		goodCommitOffset: 0,
-		badCommitOffset:  len(j.commits) - 1,
+		badCommitOffset:  len(commits.commits) - 1,
+
+		commits: commits.commits,
+		graph:   graph,
 
-		commits: j.commits,
+		probabilities: probabilities,
 
 		waitingCond: sync.NewCond(&sync.Mutex{}),
+		mutex:       &sync.Mutex{},
+
+		ctx:       ctx,
+		cancelCtx: cancelCtx,
+
+		systemChan: make(chan RunningSystem),
+		doneChan:   make(chan struct{}),
+
+		skippedCommits: make(map[string]string),
 
 		log: j.Log.WithField("replica-id", id),
 	}, nil
@@ -83,21 +140,63 @@ func createJobReplica(j *Job, index int, id string) (*replica, error) {
 
 func (r *replica) start(rsChan chan RunningSystem, ocChan chan OffendingCommit) error {
 	// Create goroutine for the replica
+	r.exitedChan = make(chan struct{})
 	go func() {
-		for !r.isStopped {
+		defer close(r.exitedChan)
+		for !r.stopped() {
 			// Check if offending commit was found, terminate if yes
 			if oc := r.getOffendingCommit(); oc != nil {
-				ocChan <- *oc
+				r.mutex.Lock()
+				if !r.isStopped {
+					close(r.doneChan)
+				}
+				r.isFinished = true
+				r.offendingCommit = oc
+				r.mutex.Unlock()
+				r.publishEvent(EventOffendingCommitFound, oc.Commit)
+				select {
+				case ocChan <- *oc:
+				case <-r.ctx.Done():
+				}
 				break
 			}
 			r.waitingCond.L.Lock()
 
 			readySystem, err := r.initNextSystem()
 			if err != nil {
+				if r.stopped() {
+					// Stopping the replica aborts initializing the system
+					r.log.Debugf("Replica %d was stopped while initializing next system - %v", r.index, err)
+					r.waitingCond.L.Unlock()
+					break
+				}
 				// TODO: What to do here?
 				r.log.Panicf("Replica %d failed to init next system - %v", r.index, err)
 			}
-			rsChan <- *readySystem
+
+			// Make sure the replica wasn't stopped while initializing the system
+			r.mutex.Lock()
+			if r.isStopped {
+				r.mutex.Unlock()
+				r.waitingCond.L.Unlock()
+				r.parentJob.ReplicaSemaphore.Release(1)
+				if err := readySystem.stop(); err != nil {
+					r.log.Warnf("Failed to stop system of commit %s - %v", readySystem.commit, err)
+				}
+				break
+			}
+			r.awaitingVerdict = true
+			r.mutex.Unlock()
+
+			r.publishEvent(EventSystemReady, readySystem.commit)
+			if !r.offerSystem(*readySystem, rsChan) {
+				// The replica was stopped before the system was received
+				r.waitingCond.L.Unlock()
+				if err := readySystem.stop(); err != nil {
+					r.log.Warnf("Failed to stop system of commit %s - %v", readySystem.commit, err)
+				}
+				break
+			}
 
 			// Wait until commit was reported to be good or bad
 			r.waitingCond.Wait()
@@ -108,70 +207,238 @@ func (r *replica) start(rsChan chan RunningSystem, ocChan chan OffendingCommit)
 	return nil
 }
 
+// stop stops the replica and removes its repository, once the replica's goroutine exited.
+// If the replica's running system was sent out but not yet rated, the semaphore acquired for it is released, s.t. jobs sharing the semaphore can continue.
 func (r *replica) stop() error {
 	// Stop goroutine
+	r.mutex.Lock()
+	if !r.isStopped && !r.isFinished {
+		close(r.doneChan)
+	}
 	r.isStopped = true
+	awaitingVerdict := r.awaitingVerdict
+	r.awaitingVerdict = false
+	r.mutex.Unlock()
+	r.cancelCtx()
+	// Signal while holding the lock, s.t. the signal isn't lost if the goroutine is about to wait
+	r.waitingCond.L.Lock()
 	r.waitingCond.Signal()
+	r.waitingCond.L.Unlock()
+
+	if awaitingVerdict {
+		// Release the in initNextSystem acquired semaphore with a weight of 1
+		r.parentJob.ReplicaSemaphore.Release(1)
+	}
+
+	// The goroutine might still be using the repository, e.g. for checking out a commit
+	if r.exitedChan != nil {
+		<-r.exitedChan
+	}
 
 	if r.lastRunningSystem != nil {
 		r.lastRunningSystem.stop()
 	}
 
+	if r.stateFile != nil {
+		r.stateFile.Close()
+	}
+
 	// Clean up tmp directory of repo
 	return os.RemoveAll(r.repoPath)
 }
 
+// stopped returns whether the replica was stopped
+func (r *replica) stopped() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.isStopped
+}
+
+// offerSystem hands out the passed running system to whoever receives it first, either from the passed channel or using [Job.NextSystem].
+// A waiting call of [Job.NextSystem] is preferred, since the passed channel may be buffered.
+// Returns false if the replica was stopped before the running system was received.
+func (r *replica) offerSystem(rs RunningSystem, rsChan chan RunningSystem) bool {
+	select {
+	case r.systemChan <- rs:
+		return true
+	default:
+	}
+
+	select {
+	case rsChan <- rs:
+		return true
+	case r.systemChan <- rs:
+		return true
+	case <-r.doneChan:
+		return false
+	}
+}
+
+// cancel stops the replica, abandoning the bisection of its issue
+func (r *replica) cancel() error {
+	r.mutex.Lock()
+	isStopped := r.isStopped
+	r.mutex.Unlock()
+	if isStopped {
+		return fmt.Errorf("replica %d was already stopped", r.index)
+	}
+
+	r.log.Infof("Cancelling replica %d", r.index)
+	return r.stop()
+}
+
+// takeVerdict returns whether the replica awaits a verdict on its running system, and if it does, stops awaiting one.
+// Verdicts on systems of stopped replicas have to be ignored, since the replica no longer holds the semaphore acquired for them.
+func (r *replica) takeVerdict() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.isStopped || !r.awaitingVerdict {
+		return false
+	}
+	r.awaitingVerdict = false
+	return true
+}
+
 func (r *replica) isGood(rs RunningSystem) {
-	if rs.commitRootOffset < r.goodCommitOffset {
+	if !r.takeVerdict() {
 		return
 	}
-	r.goodCommitOffset = rs.commitRootOffset
+	// The verdict is ignored if the commit is already known to be good, but the system is finished regardless
+	r.mutex.Lock()
+	known := rs.commitRootOffset < r.goodCommitOffset
+	r.mutex.Unlock()
+	if !known {
+		r.concludeTrial(rs, Good)
+	}
 
-	// Release the in initNextSystem acquired semaphore with a weight of 1
-	r.parentJob.replicaSemaphore.Release(1)
+	r.finishSystem(rs)
+}
 
-	go func() {
-		if err := rs.stop(); err != nil {
-			r.log.Warnf("Failed to stop container %s - %v", rs.containerName, err)
+func (r *replica) isBad(rs RunningSystem) {
+	if !r.takeVerdict() {
+		return
+	}
+	// The verdict is ignored if the commit is already known to be bad, but the system is finished regardless
+	r.mutex.Lock()
+	known := rs.commitRootOffset > r.badCommitOffset
+	r.mutex.Unlock()
+	if !known {
+		r.concludeTrial(rs, Bad)
+	}
+
+	r.finishSystem(rs)
+}
+
+// markGoodCommits marks every one of the replica's commits which is an ancestor of one of the passed commits as good
+func (r *replica) markGoodCommits(goodCommits []string) error {
+	for _, goodCommit := range goodCommits {
+		// Get all ancestors of the good commit which aren't already known to be good
+		cmd := exec.Command("git", "rev-list", goodCommit, "^"+r.commits[0])
+		cmd.Dir = r.repoPath
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return errors.Join(fmt.Errorf("failed to get rev-list of good commit %s, output: %s", goodCommit, out), err)
+		}
+		ancestors := make(map[string]bool)
+		for _, ancestor := range strings.Fields(string(out)) {
+			ancestors[ancestor] = true
 		}
-	}()
 
-	// Signal goroutine started in start() to wake up again
-	r.waitingCond.L.Lock()
-	r.waitingCond.Signal()
-	r.waitingCond.L.Unlock()
+		for i, commit := range r.commits {
+			if !ancestors[commit] {
+				continue
+			}
+			if i >= r.badCommitOffset {
+				return fmt.Errorf("good commit %s is a descendant of bad commit %s", goodCommit, r.commits[r.badCommitOffset])
+			}
+			if r.probabilities != nil {
+				// Commits known to be good are not subject to false negatives
+				r.mutex.Lock()
+				updateProbabilities(r.probabilities, i, Good, 0)
+				r.goodCommitOffset = max(r.goodCommitOffset, i)
+				r.mutex.Unlock()
+				continue
+			}
+			r.applyVerdict(i, Good)
+		}
+		r.log.Debugf("Marked ancestors of %s as good, good commit offset %d", goodCommit, r.goodCommitOffset)
+	}
+	return nil
 }
 
-func (r *replica) isBad(rs RunningSystem) {
-	if rs.commitRootOffset > r.badCommitOffset {
+// applyVerdict narrows down the replica's interval according to the verdict given to the commit at the passed offset
+func (r *replica) applyVerdict(commitOffset int, verdict Verdict) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.graph != nil {
+		switch verdict {
+		case Good:
+			r.graph.markGood(commitOffset)
+		case Bad:
+			r.graph.markBad(commitOffset)
+			r.badCommitOffset = commitOffset
+		}
 		return
 	}
-	r.badCommitOffset = rs.commitRootOffset
 
-	// Release the in initNextSystem acquired semaphore with a weight of 1
-	r.parentJob.replicaSemaphore.Release(1)
-
-	go func() {
-		if err := rs.stop(); err != nil {
-			r.log.Warnf("Failed to stop container %s - %v", rs.containerName, err)
+	if r.probabilities != nil {
+		if !updateProbabilities(r.probabilities, commitOffset, verdict, r.parentJob.FalseNegativeRate) {
+			r.log.Warnf("Verdict %s of commit %d contradicts all previous verdicts, ignoring it", verdict, commitOffset)
 		}
-	}()
+		if verdict == Bad && commitOffset < r.badCommitOffset {
+			r.badCommitOffset = commitOffset
+		}
+		return
+	}
 
-	// Signal goroutine started in start() to wake up again
-	r.waitingCond.L.Lock()
-	r.waitingCond.Signal()
-	r.waitingCond.L.Unlock()
+	switch verdict {
+	case Good:
+		if commitOffset > r.goodCommitOffset {
+			r.goodCommitOffset = commitOffset
+		}
+	case Bad:
+		if commitOffset < r.badCommitOffset {
+			r.badCommitOffset = commitOffset
+		}
+	}
 }
 
 func (r *replica) isBroken(rs RunningSystem) {
+	if !r.takeVerdict() {
+		return
+	}
 	r.replaceCommit(rs.commitRootOffset)
 
+	r.finishSystem(rs)
+}
+
+func (r *replica) isSkip(rs RunningSystem) {
+	if !r.takeVerdict() {
+		return
+	}
+	r.skipCommit(rs.commitRootOffset)
+
+	r.finishSystem(rs)
+}
+
+// release gives up the passed running system without a verdict, s.t. the replica starts a new running system in its place
+func (r *replica) release(rs RunningSystem) {
+	if !r.takeVerdict() {
+		return
+	}
+	r.log.Infof("Running system of commit %s was released without a verdict", rs.commit)
+	r.finishSystem(rs)
+}
+
+// finishSystem releases the semaphore acquired for the passed running system, stops its container and wakes up the replica to init its next system
+func (r *replica) finishSystem(rs RunningSystem) {
 	// Release the in initNextSystem acquired semaphore with a weight of 1
-	r.parentJob.replicaSemaphore.Release(1)
+	r.parentJob.ReplicaSemaphore.Release(1)
 
 	go func() {
 		if err := rs.stop(); err != nil {
-			r.log.Warnf("Failed to stop container %s - %v", rs.containerName, err)
+			r.log.Warnf("Failed to stop system of commit %s - %v", rs.commit, err)
 		}
 	}()
 
@@ -181,12 +448,42 @@ func (r *replica) isBroken(rs RunningSystem) {
 	r.waitingCond.L.Unlock()
 }
 
+// initNextSystem inits a running system of the next commit to test, acquiring the semaphore with a weight of 1 for it.
+// Commits turning out to be broken are avoided from now on, trying the next commit instead.
+// If an error is returned, the semaphore is not held.
 func (r *replica) initNextSystem() (*RunningSystem, error) {
-	// Acquire the semaphore with a weight of 1
-	r.parentJob.replicaSemaphore.Acquire(context.Background(), 1)
+	for {
+		// Acquire the semaphore with a weight of 1, unless the replica is stopped first
+		if err := r.parentJob.ReplicaSemaphore.Acquire(r.ctx, 1); err != nil {
+			return nil, err
+		}
 
-	nextCommit := r.getNextCommit()
-	commitHash := getActualCommit(r.commits[nextCommit], r.parentJob.commitReplacements)
+		rs, err := r.initSystem()
+		if rs != nil {
+			return rs, nil
+		}
+		r.parentJob.ReplicaSemaphore.Release(1)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// initSystem inits a running system of the next commit to test, for which the semaphore has to be held.
+// Returns neither a running system nor an error if the commit turned out to be broken, in which case the next commit has to be tried.
+func (r *replica) initSystem() (*RunningSystem, error) {
+	var nextCommit int
+	if len(r.trialVerdicts) != 0 {
+		// The verdict of the previous commit is not yet decided, so run another trial of it
+		nextCommit = r.trialCommitOffset
+	} else {
+		nextCommit = r.getNextCommit()
+	}
+	commitHash := r.getActualCommit(r.commits[nextCommit])
+	if r.graph != nil {
+		// Rate the commit which is actually tested, since unlike for linear bisection, the replacement isn't necessarily the next commit to test
+		nextCommit = slices.Index(r.commits, commitHash)
+	}
 
 	// Checkout new commit
 	cmd := exec.Command("sh", "-c", fmt.Sprintf("git add . && git reset --hard %s", commitHash))
@@ -211,36 +508,88 @@ func (r *replica) initNextSystem() (*RunningSystem, error) {
 
 	// Build the new image if it doesn't exist yet
 	imageName := r.parentJob.getDockerImageOfCommit(commitHash)
-	newLock := &sync.Mutex{}
-	l, _ := r.parentJob.imagesBuilding.LoadOrStore(commitHash, newLock)
-	lock := l.(*sync.Mutex)
-	lock.Lock()
-	if !r.parentJob.builtImages[imageName] {
+	unlock := r.parentJob.ImageCache.lockImage(imageName)
+	if !r.parentJob.ImageCache.isBuilt(imageName) {
 		r.log.Infof("Building image %s of commit %s", imageName, commitHash)
+		r.publishEvent(EventImageBuildStarted, commitHash)
+		r.parentJob.builds.start()
 		// Image has not been built yet
-		// TODO: Have to ensure there is no dockerfile being overwritten in dest repo
-		os.WriteFile(path.Join(r.repoPath, "Dockerfile"), []byte(r.parentJob.dockerfileString), 0777)
-		ctx, err := archive.TarWithOptions(r.repoPath, &archive.TarOptions{})
+		dockerfile := r.parentJob.getDockerfileOfCommit(commitHash)
+		dockerfileName, inContext := r.parentJob.repositoryDockerfileInContext()
+		var brokenReason string
+		if _, err := os.Stat(path.Join(r.repoPath, r.parentJob.ContextDir)); err != nil {
+			brokenReason = fmt.Sprintf("doesn't contain build context %s", r.parentJob.ContextDir)
+		} else if !dockerfile.inRepository && dockerfile.contents == "" {
+			brokenReason = fmt.Sprintf("doesn't contain dockerfile %s and there is no dockerfile to fall back to", r.parentJob.RepositoryDockerfile)
+		}
+		if brokenReason != "" {
+			r.log.Warnf("Commit hash %s %s, avoiding commit from now on", commitHash, brokenReason)
+			r.publishEvent(EventImageBuildFailed, commitHash)
+			r.replaceCommit(nextCommit)
+			r.parentJob.ImageCache.setBroken(imageName)
+			r.parentJob.builds.finish()
+			unlock()
+			return nil, nil
+		}
+		if !dockerfile.inRepository || !inContext {
+			// Place the dockerfile in the build context under a name unique to the replica, s.t. no dockerfile of the repository is overwritten
+			dockerfileName = ".biscepter-" + r.id + ".Dockerfile"
+			if err := os.WriteFile(path.Join(r.repoPath, r.parentJob.ContextDir, dockerfileName), []byte(dockerfile.contents), 0644); err != nil {
+				r.parentJob.builds.finish()
+				unlock()
+				return nil, errors.Join(fmt.Errorf("writing dockerfile for commit hash %s failed for replica %d", commitHash, r.index), err)
+			}
+		}
+		ctx, err := r.parentJob.getBuildContext(r.repoPath, dockerfileName)
 		if err != nil {
-			return nil, errors.Join(fmt.Errorf("tar creation of dockerfile for commit hash %s failed for replica %d", commitHash, r.index), err)
+			r.parentJob.builds.finish()
+			unlock()
+			return nil, errors.Join(fmt.Errorf("tar creation of build context for commit hash %s failed for replica %d", commitHash, r.index), err)
+		}
+		buildOptions := r.parentJob.getImageBuildOptions(commitHash, imageName)
+		buildOptions.Dockerfile = dockerfileName
+		closeSession := func() {}
+		if len(r.parentJob.BuildSecrets) != 0 {
+			// Secrets are only supported by BuildKit, which fetches them from a session
+			var sessionID string
+			sessionID, closeSession, err = r.parentJob.startBuildSession(apiClient)
+			if err != nil {
+				r.parentJob.builds.finish()
+				unlock()
+				return nil, err
+			}
+			buildOptions.Version = types.BuilderBuildKit
+			buildOptions.SessionID = sessionID
+		}
+		// Stopping the replica aborts the build
+		buildRes, err := apiClient.ImageBuild(r.ctx, ctx, buildOptions)
+		if err != nil && r.ctx.Err() != nil {
+			closeSession()
+			r.parentJob.builds.finish()
+			unlock()
+			return nil, err
 		}
-		buildRes, err := apiClient.ImageBuild(context.Background(), ctx, types.ImageBuildOptions{
-			Tags:        []string{imageName},
-			ForceRemove: true,
-			Labels:      map[string]string{"biscepter": "1"},
-		})
 		if err != nil {
+			closeSession()
 			out, _ := io.ReadAll(buildRes.Body)
 			logrus.Warnf("Image build of %s for commit hash %s failed, avoiding commit from now on. Build output: %s", imageName, commitHash, out)
-			r.parentJob.builtImages[imageName] = true
+			r.publishEvent(EventImageBuildFailed, commitHash)
+			r.parentJob.ImageCache.setBroken(imageName)
 			r.replaceCommit(nextCommit)
-			lock.Unlock()
-			r.parentJob.replicaSemaphore.Release(1)
-			return r.initNextSystem()
+			r.parentJob.builds.finish()
+			unlock()
+			return nil, nil
 		}
 		// Wait for build to be done
-		out, err := io.ReadAll(buildRes.Body)
+		out, err := r.readBuildOutput(buildRes.Body, commitHash)
+		closeSession()
+		if err == nil && r.ctx.Err() != nil {
+			// The build was aborted, which doesn't mean that the commit is broken
+			err = r.ctx.Err()
+		}
 		if err != nil {
+			r.parentJob.builds.finish()
+			unlock()
 			return nil, err
 		}
 		logrus.Tracef("Image build output:\n%s", string(out))
@@ -249,119 +598,97 @@ func (r *replica) initNextSystem() (*RunningSystem, error) {
 		strOut := strings.Split(string(out[:len(out)-1]), "\n")
 		if strings.HasPrefix(strOut[len(strOut)-1], `{"errorDetail"`) {
 			r.log.Warnf("Image build of %s for commit hash %s failed, avoiding commit from now on. Build output: %s", imageName, commitHash, out)
+			r.publishEvent(EventImageBuildFailed, commitHash)
 			r.replaceCommit(nextCommit)
-			// Set to true s.t. waiting replicas don't attempt to rebuild
-			r.parentJob.builtImages[imageName] = true
-			lock.Unlock()
-			r.parentJob.replicaSemaphore.Release(1)
-			return r.initNextSystem()
-		}
-		r.parentJob.builtImages[imageName] = true
-		lock.Unlock()
+			// Mark as broken s.t. waiting replicas don't attempt to rebuild
+			r.parentJob.ImageCache.setBroken(imageName)
+			r.parentJob.builds.finish()
+			unlock()
+			return nil, nil
+		}
+		r.parentJob.ImageCache.setBuilt(imageName)
+		r.publishEvent(EventImageBuildFinished, commitHash)
+		r.parentJob.builds.finish()
+		unlock()
 	} else {
-		if _, ok := r.parentJob.commitReplacements.Load(commitHash); ok {
+		if r.parentJob.ImageCache.isBroken(imageName) || r.parentJob.isBrokenCommit(commitHash) {
 			// Commit breaks the build, init another system
 			r.log.Warnf("Image for commit hash %s reported to be broken, reattempting to init next system.", commitHash)
-			lock.Unlock()
-			r.parentJob.replicaSemaphore.Release(1)
-			return r.initNextSystem()
+			if !r.parentJob.isBrokenCommit(commitHash) {
+				// The image broke for another job sharing the image cache, so this job has yet to avoid the commit
+				r.replaceCommit(nextCommit)
+			}
+			unlock()
+			return nil, nil
 		}
 		// Image has been built - reuse it
 		r.log.Infof("Image %s of commit %s already built, reusing image", imageName, commitHash)
-		lock.Unlock()
-	}
-
-	// Setup the ports
-	ports := make(map[int]int)
-	exposedPorts := make(nat.PortSet)
-	portBindings := make(nat.PortMap)
-
-	// Add all needed ports to the ports map
-	for _, healthcheck := range r.parentJob.Healthchecks {
-		ports[healthcheck.Port] = 0
-	}
-	for _, port := range r.parentJob.Ports {
-		ports[port] = 0
+		unlock()
 	}
 
-	// Assign free ports
-	for port := range ports {
-		natPort := nat.Port(fmt.Sprint(port))
-
-		freePort, err := freeport.GetFreePort()
-		if err != nil {
-			return nil, err
-		}
-
-		exposedPorts[natPort] = struct{}{}
-		portBindings[natPort] = []nat.PortBinding{{HostIP: r.parentJob.Host, HostPort: fmt.Sprint(freePort)}}
-		ports[port] = freePort
-	}
-
-	// Setup the container config
-	containerConfig := &container.Config{
-		Image:        imageName,
-		ExposedPorts: exposedPorts,
-		Labels:       map[string]string{"biscepter": "1"},
-	}
-
-	// Setup the host config
-	hostConfig := &container.HostConfig{
-		AutoRemove:   true,
-		PortBindings: portBindings,
-	}
-
-	containerName := "biscepter-" + uniuri.New()
-
-	r.log.Debugf("Exposed ports: %+v, Port bindings: %+v", exposedPorts, portBindings)
-
-	// Create the new container
-	resp, err := apiClient.ContainerCreate(context.Background(), containerConfig, hostConfig, nil, nil, containerName)
+	// Start the containers of the system's services
+	containers, servicePorts, err := r.parentJob.startSystem(apiClient, commitHash, imageName)
 	if err != nil {
-		return nil, errors.Join(fmt.Errorf("container creation with name %s of image %s failed for replica %d", containerName, imageName, r.index), err)
-	}
-
-	// Start the new container
-	if err := apiClient.ContainerStart(context.Background(), resp.ID, container.StartOptions{}); err != nil {
-		return nil, errors.Join(fmt.Errorf("container start with name %s and id %s of image %s failed for replica %d", containerName, resp.ID, imageName, r.index), err)
+		return nil, errors.Join(fmt.Errorf("system of commit %s failed to start for replica %d", commitHash, r.index), err)
 	}
 
-	r.log.Infof("Started container %s running commit %s, performing healthchecks...", containerName, commitHash)
+	r.log.Infof("Started containers %v running commit %s, performing healthchecks...", containers.names, commitHash)
+	r.publishEvent(EventContainerStarted, commitHash)
 
 	// Perform healthchecks
 	for _, healthcheck := range r.parentJob.Healthchecks {
-		success, err := healthcheck.performHealthcheck(ports, r.log)
+		success, err := healthcheck.performHealthcheck(servicePorts[r.parentJob.healthcheckService(healthcheck)], servicePorts, r.log)
 		if !success {
+			r.publishEvent(EventHealthcheckFailed, commitHash)
 			r.replaceCommit(nextCommit)
 			logrus.Warnf("healthcheck on port %d failed for replica %d, treating commit %s as broken", healthcheck.Port, r.index, r.commits[nextCommit])
-			return r.initNextSystem()
+			if err := containers.stop(apiClient); err != nil {
+				r.log.Warnf("Failed to stop system of commit %s - %v", commitHash, err)
+			}
+			return nil, nil
 		} else if err != nil {
+			if err := containers.stop(apiClient); err != nil {
+				r.log.Warnf("Failed to stop system of commit %s - %v", commitHash, err)
+			}
 			return nil, err
 		}
 	}
 
-	r.log.Infof("Successfully performed healthchecks on container %s running commit %s", containerName, commitHash)
+	r.log.Infof("Successfully performed healthchecks on containers %v running commit %s", containers.names, commitHash)
 
 	rs := &RunningSystem{
 		ReplicaIndex: r.index,
+		TrialIndex:   len(r.trialVerdicts),
 
-		Ports: ports,
+		Ports: servicePorts[r.parentJob.mainService()],
 
 		parentReplica: r,
 
-		containerName: containerName,
+		containers: containers,
 
 		commit:           commitHash,
 		commitRootOffset: nextCommit,
 	}
+	if len(r.parentJob.Services) != 0 {
+		rs.ServicePorts = servicePorts
+	}
 
+	r.mutex.Lock()
 	r.lastRunningSystem = rs
+	r.mutex.Unlock()
 
 	return rs, nil
 }
 
 // getNextCommit returns the next commit which should be used for bisection
 func (r replica) getNextCommit() int {
+	if r.graph != nil {
+		return r.getNextDAGCommit()
+	}
+	if r.probabilities != nil {
+		return r.getNextProbabilisticCommit()
+	}
+
 	nextCommit := (r.goodCommitOffset + r.badCommitOffset) / 2
 
 	// Find closest cached build
@@ -370,11 +697,11 @@ func (r replica) getNextCommit() int {
 		commitAbove := r.parentJob.getDockerImageOfCommit(r.commits[nextCommit+i])
 		commitBelow := r.parentJob.getDockerImageOfCommit(r.commits[nextCommit-i])
 
-		if r.parentJob.builtImages[commitAbove] {
+		if r.parentJob.ImageCache.isBuilt(commitAbove) {
 			// If a commit above the middle is built
 			offset = i
 			break
-		} else if r.parentJob.builtImages[commitBelow] && nextCommit-i > r.goodCommitOffset {
+		} else if r.parentJob.ImageCache.isBuilt(commitBelow) && nextCommit-i > r.goodCommitOffset {
 			// If a commit below the middle is built. Since nextCommit rounds down, we have to check we're not testing the same commit again
 			offset = -i
 			break
@@ -415,7 +742,7 @@ func (r replica) getNextCommit() int {
 		// Get the fraction of cached vs uncached commits
 		cached := 0
 		for i := r.goodCommitOffset + 1; i < r.badCommitOffset-1; i++ {
-			if r.parentJob.builtImages[r.parentJob.getDockerImageOfCommit(r.commits[i])] {
+			if r.parentJob.ImageCache.isBuilt(r.parentJob.getDockerImageOfCommit(r.commits[i])) {
 				cached++
 			}
 		}
@@ -445,59 +772,75 @@ func (r replica) getNextCommit() int {
 // getOffendingCommit returns the offending commit for the issue bisected by the replica if it was found.
 // If no offending commit was yet found, returns nil
 func (r *replica) getOffendingCommit() *OffendingCommit {
+	if r.graph != nil {
+		return r.getOffendingDAGCommit()
+	}
+
 	// Offending commit not yet found
-	if r.badCommitOffset > r.goodCommitOffset+1 {
+	commitOffset, found := r.foundCommitOffset()
+	if !found {
 		return nil
 	}
 
-	commitHash := getActualCommit(r.commits[r.badCommitOffset], r.parentJob.commitReplacements)
-	prevCommitHash := getActualCommit(r.commits[r.badCommitOffset-1], r.parentJob.commitReplacements)
+	commitHash := r.getActualCommit(r.commits[commitOffset])
+	prevCommitHash := r.getActualCommit(r.commits[commitOffset-1])
 
 	// Get all commits that alias to the current one
 	curCommit := commitHash
 	for {
-		found := false
-		r.parentJob.commitReplacements.Range(
-			func(key, value any) bool {
-				commit := key.(string)
-				replacement := value.(string)
-				if replacement == curCommit {
-					curCommit = commit
-					r.possibleOtherCommits = append(r.possibleOtherCommits, commit)
-					found = true
-					return false
-				}
-				return true
-			},
-		)
+		commit, found := r.getReplacedCommit(curCommit)
 		if !found {
 			break
 		}
+		curCommit = commit
+		r.possibleOtherCommits = append(r.possibleOtherCommits, commit)
 	}
 
-	// TODO: Maybe toggle this off with a flag? Or specify a max depth of bisecting merges? Also document that octopus merges are not supported.
-	// TODO: Check if commit is a merge commit but no octopus commit, bisect merge branch if yes
+	if r.descendIntoMerge(commitHash, prevCommitHash) {
+		return nil
+	}
 
-	mergeParent, err := getMergedParent(commitHash, prevCommitHash, r.repoPath)
-	if err != nil {
-		r.log.Errorf("Failed to get merge parent of %s - %v", commitHash, err)
+	oc := r.newOffendingCommit(commitOffset, commitHash)
+	if r.probabilities != nil {
+		oc.Confidence = r.probabilities[commitOffset]
+	}
+	return oc
+}
+
+// foundCommitOffset returns the offset of the offending commit among the replica's current commits and whether it was found.
+// When bisecting with [ProbabilisticBisection], this is the most probable commit once its probability reaches the job's confidence threshold.
+// Otherwise, it is the oldest bad commit once it directly follows the newest good commit.
+func (r *replica) foundCommitOffset() (int, bool) {
+	if r.probabilities != nil {
+		commitOffset, probability := mostProbableCommit(r.probabilities)
+		return commitOffset, probability >= r.parentJob.ConfidenceThreshold
 	}
+	return r.badCommitOffset, r.badCommitOffset <= r.goodCommitOffset+1
+}
 
-	if mergeParent != "" {
-		r.log.Infof("Offending commit %s is a merge commit. Merged parent: %s", commitHash, mergeParent)
-		var err error
-		r.commits, err = getCommitsBetween(prevCommitHash, mergeParent, r.repoPath)
-		if err != nil {
-			r.log.Panicf("couldn't get replica's merge commits - %v", err)
-		}
-		r.goodCommitOffset = 0
-		r.badCommitOffset = len(r.commits) - 1
+// getOffendingDAGCommit returns the offending commit for the issue bisected by the replica if it was found when bisecting with [DAGBisection].
+// If no offending commit was yet found, returns nil
+func (r *replica) getOffendingDAGCommit() *OffendingCommit {
+	// Offending commit not yet found
+	if !r.dagBisectionFinished() {
 		return nil
 	}
 
+	// Every candidate left except for the bad commit breaks the build
+	for i, isCandidate := range r.graph.candidates {
+		if isCandidate && i != r.badCommitOffset {
+			r.possibleOtherCommits = append(r.possibleOtherCommits, r.commits[i])
+		}
+	}
+
+	return r.newOffendingCommit(r.badCommitOffset, r.commits[r.badCommitOffset])
+}
+
+// newOffendingCommit creates the offending commit reported by this replica for the passed commit hash at the passed offset
+func (r *replica) newOffendingCommit(commitOffset int, commitHash string) *OffendingCommit {
 	// Get additional info about the commit
 	var commitMsg, commitDate, commitAuthor string
-	cmd := exec.Command("git", "--no-pager", "show", "-s", "--format=%B%n%aD%n%an <%ae>", getActualCommit(commitHash, r.parentJob.commitReplacements))
+	cmd := exec.Command("git", "--no-pager", "show", "-s", "--format=%B%n%aD%n%an <%ae>", commitHash)
 	cmd.Dir = r.repoPath
 	outBytes, err := cmd.CombinedOutput()
 	if err != nil {
@@ -518,19 +861,112 @@ func (r *replica) getOffendingCommit() *OffendingCommit {
 		}
 	}
 
-	r.log.Infof("Found offending commit %s with offset %d. Message: %q, Date: %q, Author: %q", commitHash, r.badCommitOffset, commitMsg, commitDate, commitAuthor)
+	r.log.Infof("Found offending commit %s with offset %d. Message: %q, Date: %q, Author: %q", commitHash, commitOffset, commitMsg, commitDate, commitAuthor)
 
 	return &OffendingCommit{
 		ReplicaIndex: r.index,
 
 		Commit:       commitHash,
-		CommitOffset: r.badCommitOffset,
+		CommitOffset: commitOffset,
+		Confidence:   1,
 
 		CommitMessage: commitMsg,
 		CommitDate:    commitDate,
 		CommitAuthor:  commitAuthor,
 
 		PossibleOtherCommits: r.possibleOtherCommits,
+
+		MergeChain: r.mergeChain,
+
+		OctopusMerge:        r.octopusOffendingMerge,
+		OctopusMergedBranch: r.octopusOffendingTip,
+	}
+}
+
+// descendIntoMerge checks whether the passed offending commit is a merge commit, given its passed parent on the bisected branch.
+// If it is, the replica's commits are set to the ones of the merged branch and bisection continues there.
+// For octopus merges, the merged branches are bisected one after another, each ending in the octopus merge itself.
+// If bisecting a branch results in the octopus merge being the offending commit, the branch did not introduce the issue and the next branch is bisected.
+// Returns whether the replica continues bisecting in a merged branch.
+func (r *replica) descendIntoMerge(commitHash, prevCommitHash string) bool {
+	if r.octopusMerge != "" {
+		if commitHash == r.octopusMerge {
+			// The current branch of the octopus merge did not introduce the issue
+			if r.octopusBranchIndex+1 < len(r.octopusBranches) {
+				r.bisectOctopusBranch(r.octopusBranchIndex + 1)
+				return true
+			}
+			r.log.Infof("None of the merged branches of octopus merge %s introduced the issue on their own", r.octopusMerge)
+			r.octopusMerge = ""
+			// The octopus merge itself is offending, so it is no longer part of the chain of merges descended into
+			r.mergeChain = r.mergeChain[:len(r.mergeChain)-1]
+			return false
+		}
+
+		// The offending commit lies within the current branch of the octopus merge
+		r.log.Infof("Merged branch %s of octopus merge %s introduced the issue", r.octopusBranches[r.octopusBranchIndex], r.octopusMerge)
+		r.octopusOffendingMerge = r.octopusMerge
+		r.octopusOffendingTip = r.octopusBranches[r.octopusBranchIndex]
+		r.octopusMerge = ""
+	}
+
+	mergeParents, err := getMergedParents(commitHash, prevCommitHash, r.repoPath)
+	if err != nil {
+		r.log.Errorf("Failed to get merge parents of %s - %v", commitHash, err)
+	}
+
+	if len(mergeParents) == 0 {
+		return false
+	}
+
+	if !r.parentJob.allowsMergeDescent(len(r.mergeChain)) {
+		r.log.Infof("Offending commit %s is a merge commit, but the merge descent policy prevents descending into it", commitHash)
+		return false
+	}
+	r.mergeChain = append(r.mergeChain, commitHash)
+
+	if len(mergeParents) > 1 {
+		r.log.Infof("Offending commit %s is an octopus merge commit. Merged parents: %s", commitHash, strings.Join(mergeParents, ", "))
+		r.octopusMerge = commitHash
+		r.octopusBase = prevCommitHash
+		r.octopusBranches = mergeParents
+		r.bisectOctopusBranch(0)
+		return true
+	}
+
+	r.log.Infof("Offending commit %s is a merge commit. Merged parent: %s", commitHash, mergeParents[0])
+	commits, err := getCommitsBetween(prevCommitHash, mergeParents[0], r.repoPath)
+	if err != nil {
+		r.log.Panicf("couldn't get replica's merge commits - %v", err)
+	}
+	r.setCommits(commits)
+	return true
+}
+
+// bisectOctopusBranch sets the replica's commits to the ones of the branch with the passed index merged by the current octopus merge.
+// The octopus merge itself is appended to the commits, s.t. bisecting a branch which did not introduce the issue results in the octopus merge being offending.
+func (r *replica) bisectOctopusBranch(branchIndex int) {
+	r.octopusBranchIndex = branchIndex
+	tip := r.octopusBranches[branchIndex]
+
+	r.log.Infof("Bisecting merged branch %s (%d/%d) of octopus merge %s", tip, branchIndex+1, len(r.octopusBranches), r.octopusMerge)
+	commits, err := getCommitsBetween(r.octopusBase, tip, r.repoPath)
+	if err != nil {
+		r.log.Panicf("couldn't get replica's octopus merge commits - %v", err)
+	}
+	r.setCommits(append(commits, r.octopusMerge))
+}
+
+// setCommits sets the commits bisected by the replica, where commits[0] is the good commit and commits[N-1] is the bad commit, and resets its progress
+func (r *replica) setCommits(commits []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.commits = commits
+	r.goodCommitOffset = 0
+	r.badCommitOffset = len(commits) - 1
+	if r.probabilities != nil {
+		r.probabilities = newProbabilities(len(commits))
 	}
 }
 
@@ -541,40 +977,243 @@ func (r *replica) getOffendingCommit() *OffendingCommit {
 //
 //	commitOffset >= len(commits) - 1
 func (r *replica) replaceCommit(commitOffset int) {
+	cur, next := r.getCommitReplacement(commitOffset)
+
+	r.log.Debugf("Adding new replacement: %s -> %s", cur, next)
+
+	if r.graph != nil {
+		// The replacement is the commit's oldest child in this replica's graph, which other replicas don't necessarily share.
+		// Thus only note the commit as broken, letting every replica derive its own replacement
+		r.parentJob.brokenCommits.Store(cur, true)
+	} else {
+		// Store in replacements file for reuse in later runs
+		r.parentJob.commitReplacementsBackupFile.WriteString(fmt.Sprintf("%s:%s,", cur, next))
+
+		r.parentJob.commitReplacements.Store(cur, next)
+	}
+
+	r.parentJob.publishEvent(Event{
+		Type: EventCommitReplaced,
+
+		ReplicaIndex: r.index,
+		Commit:       cur,
+
+		Replacement: next,
+	})
+}
+
+// skipCommit makes note of the passed commit as untestable for this replica's issue.
+// Unlike with replaceCommit, the replacement is neither persisted nor shared with other replicas.
+//
+// Just like replaceCommit, this function panics if
+//
+//	commitOffset >= len(commits) - 1
+func (r *replica) skipCommit(commitOffset int) {
+	cur, next := r.getCommitReplacement(commitOffset)
+
+	r.log.Debugf("Adding new skipped commit: %s -> %s", cur, next)
+
+	r.mutex.Lock()
+	r.skippedCommits[cur] = next
+	r.mutex.Unlock()
+
+	r.parentJob.publishEvent(Event{
+		Type: EventCommitSkipped,
+
+		ReplicaIndex: r.index,
+		Commit:       cur,
+
+		Replacement: next,
+	})
+}
+
+// getCommitReplacement returns the actual commit of the commit at the passed offset, along with the commit it should be replaced with.
+// Any trials of the replaced commit are discarded, as it is avoided from now on.
+//
+// This function panics if
+//
+//	commitOffset >= len(commits) - 1
+func (r *replica) getCommitReplacement(commitOffset int) (string, string) {
 	if commitOffset >= len(r.commits)-1 {
 		logrus.Panicf("Passed commit offset %d to replaceCommit is too large! Max allowed length :%d", commitOffset, len(r.commits)-2)
 	}
 
+	r.trialVerdicts = nil
+
+	if r.graph != nil {
+		return r.getDAGCommitReplacement(commitOffset)
+	}
+
 	// Get the offset of the actual commit to replace
 	cur := r.commits[commitOffset]
 	for {
-		if val, ok := r.parentJob.commitReplacements.Load(cur); ok {
-			cur = val.(string)
+		if val, ok := r.getReplacement(cur); ok {
+			cur = val
 			commitOffset++
 		} else {
 			break
 		}
 	}
 
-	next := r.commits[commitOffset+1]
+	return cur, r.commits[commitOffset+1]
+}
 
-	// Store in replacements file for reuse in later runs
-	r.parentJob.commitReplacementsBackupFile.WriteString(fmt.Sprintf("%s:%s,", cur, next))
+// getDAGCommitReplacement returns the actual commit of the commit at the passed offset, along with the commit it should be replaced with when bisecting with [DAGBisection].
+// The commit is replaced with its oldest child, since unlike for linear bisection, there is no single next commit.
+func (r *replica) getDAGCommitReplacement(commitOffset int) (string, string) {
+	// Get the offset of the actual commit to replace
+	for {
+		val, ok := r.getReplacement(r.commits[commitOffset])
+		if !ok {
+			break
+		}
+		commitOffset = slices.Index(r.commits, val)
+		if commitOffset == -1 {
+			logrus.Panicf("Replacement %s is not within the commits of replica %d", val, r.index)
+		}
+	}
 
-	r.log.Debugf("Adding new replacement: %s -> %s", cur, next)
+	childOffset := r.graph.child(commitOffset)
+	if childOffset == -1 {
+		logrus.Panicf("Passed commit offset %d to replaceCommit has no child to replace it with", commitOffset)
+	}
+	return r.commits[commitOffset], r.commits[childOffset]
+}
 
-	r.parentJob.commitReplacements.Store(cur, next)
+// getReplacement returns the commit which the passed commit should be replaced with, either because it breaks the build or because it was skipped by this replica.
+// Returns false if the commit should not be replaced.
+func (r *replica) getReplacement(commitHash string) (string, bool) {
+	if val, ok := r.getBrokenReplacement(commitHash); ok {
+		return val, true
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	replacement, ok := r.skippedCommits[commitHash]
+	return replacement, ok
+}
+
+// getBrokenReplacement returns the commit which the passed commit should be replaced with because it breaks the build.
+// Returns false if the commit isn't known to break the build.
+//
+// When bisecting with [DAGBisection], the replacement is the commit's oldest child in the replica's graph.
+// The job's replacements are the next first-parent commits, which aren't necessarily part of the graph, so only their keys are used.
+func (r *replica) getBrokenReplacement(commitHash string) (string, bool) {
+	if r.graph == nil {
+		val, ok := r.parentJob.commitReplacements.Load(commitHash)
+		if !ok {
+			return "", false
+		}
+		return val.(string), true
+	}
+
+	if !r.parentJob.isBrokenCommit(commitHash) {
+		return "", false
+	}
+	commitOffset := slices.Index(r.commits, commitHash)
+	if commitOffset == -1 {
+		return "", false
+	}
+	childOffset := r.graph.child(commitOffset)
+	if childOffset == -1 {
+		return "", false
+	}
+	return r.commits[childOffset], true
+}
+
+// getReplacedCommit returns the commit which is replaced with the passed commit, or false if there is none
+func (r *replica) getReplacedCommit(replacement string) (string, bool) {
+	replaced, found := "", false
+	r.parentJob.commitReplacements.Range(
+		func(key, value any) bool {
+			if value.(string) == replacement {
+				replaced, found = key.(string), true
+				return false
+			}
+			return true
+		},
+	)
+	if found {
+		return replaced, true
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for commit, skipReplacement := range r.skippedCommits {
+		if skipReplacement == replacement {
+			return commit, true
+		}
+	}
+	return "", false
+}
+
+// getActualCommit returns the hash of the commit which the passed commit results in, given the job's replacements and the commits skipped by this replica
+func (r *replica) getActualCommit(commitHash string) string {
+	for {
+		replacement, ok := r.getReplacement(commitHash)
+		if !ok {
+			return commitHash
+		}
+		commitHash = replacement
+	}
+}
+
+// readBuildOutput reads the passed output of the build of the passed commit until the build is done, publishing every line of it.
+// Returns the whole output, consisting of one JSON message per line.
+func (r *replica) readBuildOutput(body io.Reader, commitHash string) ([]byte, error) {
+	var out []byte
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadBytes('\n')
+		out = append(out, line...)
+
+		var message struct {
+			Stream string `json:"stream"`
+			Error  string `json:"error"`
+
+			// Set for the progress of BuildKit builds
+			ID  string          `json:"id"`
+			Aux json.RawMessage `json:"aux"`
+		}
+		if json.Unmarshal(line, &message) == nil {
+			output := message.Stream + message.Error
+			if message.ID == "moby.buildkit.trace" {
+				output = buildkitTraceOutput(message.Aux)
+			}
+			if output != "" {
+				r.parentJob.publishEvent(Event{
+					Type: EventImageBuildOutput,
+
+					ReplicaIndex: r.index,
+					Commit:       commitHash,
+
+					Output: output,
+				})
+			}
+		}
+
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
 }
 
 // A RunningSystem is a running system that is ready to be tested
 type RunningSystem struct {
 	ReplicaIndex int // The index of this system's parent replica
+	TrialIndex   int // The index of this system among the trials of its commit, starting at 0. Always 0 if the job has a single trial per commit
 
 	Ports map[int]int // A mapping of the ports specified for the system under test to the ones they were mapped to locally
+	// The mappings of the ports of each service to the ones they were mapped to locally, keyed by the name of the service.
+	// Only set if the job has services, in which case Ports holds the ports of the first service built from the bisected commit
+	ServicePorts map[string]map[int]int
 
 	parentReplica *replica
 
-	containerName string // The name of the container running this system
+	containers systemContainers // The containers running this system
 
 	commit           string // The current commit
 	commitRootOffset int    // The offset of the current commit to the root commit
@@ -582,6 +1221,11 @@ type RunningSystem struct {
 	wasRated bool // If this system was already specified to be either good or bad
 }
 
+// Commit returns the hash of the commit this system is running
+func (r RunningSystem) Commit() string {
+	return r.commit
+}
+
 // IsGood tells biscepter that this running system is good.
 // If IsGood is called after the running system was already rated by a previous IsGood or IsBad method invocation, it will panic.
 func (r *RunningSystem) IsGood() {
@@ -612,6 +1256,57 @@ func (r *RunningSystem) IsBroken() {
 	r.parentReplica.isBroken(*r)
 }
 
+// IsSkip tells biscepter that this running system can't be tested for the issue bisected by its replica, e.g. because a feature needed to test it is missing.
+// Unlike with IsBroken, the commit is only avoided by this system's replica, and not persisted for subsequent runs.
+// If IsSkip is called after the running system was already rated, it will panic.
+func (r *RunningSystem) IsSkip() {
+	if r.wasRated {
+		panic(fmt.Sprintf("IsSkip was called on running system of replica with index %d after it was already rated", r.ReplicaIndex))
+	}
+	r.wasRated = true
+	r.parentReplica.isSkip(*r)
+}
+
+// Release gives up this running system without rating it, e.g. because whoever was supposed to test it is gone.
+// The system's container is stopped and its replica starts a new running system in its place.
+// If Release is called after the running system was already rated, it will panic.
+func (r *RunningSystem) Release() {
+	if r.wasRated {
+		panic(fmt.Sprintf("Release was called on running system of replica with index %d after it was already rated", r.ReplicaIndex))
+	}
+	r.wasRated = true
+	r.parentReplica.release(*r)
+}
+
+// RunTest runs the test of this system's job against this running system and rates it according to the test's result.
+// RunTest blocks until the test script has finished and returns the verdict the running system was rated with.
+// If the job has no test or the test script fails to give a verdict, an error is returned and the running system is not rated.
+// If RunTest is called after the running system was already rated, it will panic.
+func (r *RunningSystem) RunTest() (Verdict, error) {
+	test := r.parentReplica.parentJob.Test
+	if test == nil {
+		return 0, fmt.Errorf("job of replica with index %d has no test", r.ReplicaIndex)
+	}
+
+	r.parentReplica.log.Infof("Running test against commit %s", r.commit)
+	verdict, out, err := test.performTest(r.Ports, r.ServicePorts)
+	r.parentReplica.log.Debugf("Test output for commit %s:\n%s", r.commit, out)
+	if err != nil {
+		return 0, err
+	}
+	r.parentReplica.log.Infof("Test rated commit %s as %s", r.commit, verdict)
+
+	switch verdict {
+	case Good:
+		r.IsGood()
+	case Bad:
+		r.IsBad()
+	case Broken:
+		r.IsBroken()
+	}
+	return verdict, nil
+}
+
 func (r RunningSystem) stop() error {
 	// Create docker client
 	apiClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
@@ -620,10 +1315,7 @@ func (r RunningSystem) stop() error {
 	}
 	defer apiClient.Close()
 
-	if err := apiClient.ContainerStop(context.Background(), r.containerName, container.StopOptions{}); err != nil {
-		return err
-	}
-	return nil
+	return r.containers.stop(apiClient)
 }
 
 // An OffendingCommit represents the finished bisection of a replica.
@@ -638,4 +1330,11 @@ type OffendingCommit struct {
 	CommitAuthor  string // The author of the offending commit
 
 	PossibleOtherCommits []string // Other possible offending commits. Set if there were build failures causing uncertainty in the exact offending commit
+
+	MergeChain []string // The merge commits descended into to find the offending commit, starting with the outermost one. Empty if no merge was descended into
+
+	OctopusMerge        string // The octopus merge whose merged branch contains the offending commit, or empty if the offending commit was not found by bisecting an octopus merge
+	OctopusMergedBranch string // The tip of the branch merged by OctopusMerge which contains the offending commit, or empty if OctopusMerge is empty
+
+	Confidence float64 // The probability of Commit being the offending commit. Always 1 unless bisecting with ProbabilisticBisection
 }
@@ -1,10 +1,16 @@
 package biscepter
 
 import (
+	"context"
+	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
 )
 
 func TestGetNextCommit(t *testing.T) {
@@ -31,12 +37,12 @@ func TestGetNextCommit(t *testing.T) {
 			commits:          v.commits,
 			log:              logrus.NewEntry(logrus.StandardLogger()),
 			parentJob: &Job{
-				BuildCost:   v.buildCost,
-				builtImages: make(map[string]bool),
+				BuildCost:  v.buildCost,
+				ImageCache: &ImageCache{built: make(map[string]bool)},
 			},
 		}
 		for _, image := range v.built {
-			rep.parentJob.builtImages[rep.parentJob.getDockerImageOfCommit(image)] = true
+			rep.parentJob.ImageCache.built[rep.parentJob.getDockerImageOfCommit(image)] = true
 		}
 
 		logrus.SetLevel(logrus.TraceLevel)
@@ -44,3 +50,180 @@ func TestGetNextCommit(t *testing.T) {
 		assert.Equalf(t, v.expectedIndex, rep.getNextCommit(), "GetNextCommit returned wrong offset for test %d; goodCommit: %d, badCommit: %d, commits: %v, built: %v, buildCost: %f", i, v.goodCommitOffset, v.badCommitOffset, v.commits, v.built, v.buildCost)
 	}
 }
+
+func TestRestoreState(t *testing.T) {
+	values := []struct {
+		state string
+
+		expectedGoodCommitOffset int
+		expectedBadCommitOffset  int
+		expectError              bool
+	}{
+		{"", 0, 6, false},
+		{"c:bad,", 0, 3, false},
+		{"c:good,e:bad,", 3, 5, false},
+		{"c:bad,a:good,b:good,", 2, 3, false},
+		{"c:meh,", 0, 6, true},
+		{"x:good,", 0, 6, true},
+		{"c", 0, 6, true},
+	}
+
+	for i, v := range values {
+		rep := replica{
+			goodCommitOffset: 0,
+			badCommitOffset:  6,
+			commits:          []string{"padl", "a", "b", "c", "d", "e", "padr"},
+			log:              logrus.NewEntry(logrus.StandardLogger()),
+			mutex:            &sync.Mutex{},
+			parentJob: &Job{
+				commitReplacements: &sync.Map{},
+			},
+		}
+
+		err := rep.restoreState(v.state)
+		if v.expectError {
+			assert.Errorf(t, err, "restoreState didn't return an error for test %d; state: %q", i, v.state)
+			continue
+		}
+		assert.NoErrorf(t, err, "restoreState returned an error for test %d; state: %q", i, v.state)
+		assert.Equalf(t, v.expectedGoodCommitOffset, rep.goodCommitOffset, "restoreState restored wrong good commit offset for test %d; state: %q", i, v.state)
+		assert.Equalf(t, v.expectedBadCommitOffset, rep.badCommitOffset, "restoreState restored wrong bad commit offset for test %d; state: %q", i, v.state)
+	}
+}
+
+func TestMarkGoodCommits(t *testing.T) {
+	repo := createTestRepo(t,
+		"commit -q --allow-empty -m good",
+		"commit -q --allow-empty -m a",
+		"commit -q --allow-empty -m b",
+		"branch fix",
+		"commit -q --allow-empty -m c",
+		"commit -q --allow-empty -m bad",
+		"checkout -q fix",
+		"commit -q --allow-empty -m fix",
+		"checkout -q main",
+	)
+
+	commits, err := getCommitsBetween(revParse(t, repo, "main~4"), revParse(t, repo, "main"), repo)
+	assert.NoError(t, err, "getCommitsBetween returned an error")
+
+	newReplica := func() *replica {
+		return &replica{
+			repoPath:        repo,
+			badCommitOffset: len(commits) - 1,
+			commits:         commits,
+			log:             logrus.NewEntry(logrus.StandardLogger()),
+			mutex:           &sync.Mutex{},
+		}
+	}
+
+	t.Run("Ancestors of good commits are good", func(t *testing.T) {
+		rep := newReplica()
+		assert.NoError(t, rep.markGoodCommits([]string{revParse(t, repo, "fix"), revParse(t, repo, "main~3")}), "markGoodCommits returned an error")
+		assert.Equal(t, 2, rep.goodCommitOffset, "Wrong good commit offset after marking good commits")
+		assert.Equal(t, 4, rep.badCommitOffset, "Bad commit offset changed after marking good commits")
+	})
+	t.Run("Descendants of the bad commit error", func(t *testing.T) {
+		rep := newReplica()
+		assert.Error(t, rep.markGoodCommits([]string{revParse(t, repo, "main")}), "Marking the bad commit good didn't raise an error")
+	})
+}
+
+func TestSkipCommit(t *testing.T) {
+	job := &Job{
+		commitReplacements: &sync.Map{},
+	}
+	job.commitReplacements.Store("b", "c")
+
+	newReplica := func() *replica {
+		return &replica{
+			badCommitOffset: 5,
+			commits:         []string{"good", "a", "b", "c", "d", "bad"},
+			log:             logrus.NewEntry(logrus.StandardLogger()),
+			parentJob:       job,
+			skippedCommits:  make(map[string]string),
+			mutex:           &sync.Mutex{},
+		}
+	}
+	rep, otherRep := newReplica(), newReplica()
+
+	rep.skipCommit(2)
+	assert.Equal(t, "d", rep.getActualCommit("b"), "Skipped commit wasn't replaced")
+	assert.Equal(t, "c", otherRep.getActualCommit("b"), "Skipped commit was replaced for other replica")
+	_, found := job.commitReplacements.Load("c")
+	assert.False(t, found, "Skipped commit was stored in the job's replacements")
+
+	replaced, found := rep.getReplacedCommit("d")
+	assert.True(t, found, "Commit replaced by skipped commit's replacement wasn't found")
+	assert.Equal(t, "c", replaced, "Wrong commit replaced by skipped commit's replacement")
+}
+
+func TestStopWaitsForReplica(t *testing.T) {
+	job := &Job{
+		Log:              logrus.New(),
+		ReplicaSemaphore: semaphore.NewWeighted(1),
+		repoPath:         t.TempDir(),
+	}
+	// Occupy the semaphore, s.t. the replica blocks while initializing its first system
+	require.NoError(t, job.ReplicaSemaphore.Acquire(context.Background(), 1))
+
+	rep, err := createJobReplica(job, 0, "0", Issue{}, bisectedCommits{commits: []string{"good", "a", "bad"}})
+	require.NoError(t, err, "createJobReplica returned an error")
+	require.NoError(t, rep.start(make(chan RunningSystem), make(chan OffendingCommit)), "start returned an error")
+
+	stopped := make(chan error)
+	go func() { stopped <- rep.stop() }()
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err, "stop returned an error")
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop didn't return while the replica was waiting for the semaphore")
+	}
+
+	select {
+	case <-rep.exitedChan:
+	default:
+		t.Error("Replica's goroutine didn't exit before stop returned")
+	}
+	_, err = os.Stat(rep.repoPath)
+	assert.True(t, os.IsNotExist(err), "Replica's repository wasn't removed")
+
+	// The stopped replica must not have kept the semaphore
+	job.ReplicaSemaphore.Release(1)
+	assert.True(t, job.ReplicaSemaphore.TryAcquire(1), "Stopped replica holds the semaphore")
+}
+
+func TestVerdictOnKnownCommit(t *testing.T) {
+	job := &Job{ReplicaSemaphore: semaphore.NewWeighted(1)}
+	rep := &replica{
+		goodCommitOffset: 2,
+		badCommitOffset:  4,
+		commits:          []string{"padl", "a", "b", "c", "d", "padr"},
+		log:              logrus.NewEntry(logrus.StandardLogger()),
+		mutex:            &sync.Mutex{},
+		waitingCond:      sync.NewCond(&sync.Mutex{}),
+		parentJob:        job,
+	}
+
+	// Commit a is older than the good commit, and the right padding is newer than the bad commit
+	values := []struct {
+		rate         func(RunningSystem)
+		commitOffset int
+	}{
+		{rep.isGood, 1},
+		{rep.isBad, 5},
+	}
+
+	for i, v := range values {
+		require.NoError(t, job.ReplicaSemaphore.Acquire(context.Background(), 1))
+		rep.awaitingVerdict = true
+
+		v.rate(RunningSystem{commitRootOffset: v.commitOffset})
+
+		assert.Falsef(t, rep.awaitingVerdict, "Replica still awaits a verdict after rating its system for test %d", i)
+		assert.Truef(t, job.ReplicaSemaphore.TryAcquire(1), "Semaphore wasn't released after rating a known commit for test %d", i)
+		job.ReplicaSemaphore.Release(1)
+	}
+	assert.Equal(t, 2, rep.goodCommitOffset, "Verdict on known commit changed the good commit offset")
+	assert.Equal(t, 4, rep.badCommitOffset, "Verdict on known commit changed the bad commit offset")
+}
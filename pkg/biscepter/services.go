@@ -0,0 +1,310 @@
+package biscepter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dchest/uniuri"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/phayes/freeport"
+)
+
+// A Service is one of the containers making up the running systems of a job, e.g. the app built from the bisected commit or a database it depends on
+type Service struct {
+	Name  string // The name of the service, under which the other services of the same system can reach it
+	Image string // The fixed image run by the service, e.g. postgres:16, or empty if the service runs the image built from the bisected commit
+
+	Ports      []int             // The ports of the service which are mapped to the host
+	Env        map[string]string // The environment variables set in the service's container. Occurrences of ${COMMIT} in their values are replaced with the hash of the running commit
+	Volumes    []string          // The volumes mounted into the service's container, in the format of [Job.Volumes]
+	Command    []string          // The command run by the service's container instead of the image's CMD, or nil to keep it
+	Entrypoint []string          // The entrypoint of the service's container instead of the image's ENTRYPOINT, or nil to keep it
+}
+
+type serviceYaml struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+
+	Ports      []int             `yaml:"ports"`
+	Env        map[string]string `yaml:"env"`
+	Volumes    []string          `yaml:"volumes"`
+	Command    []string          `yaml:"command"`
+	Entrypoint []string          `yaml:"entrypoint"`
+}
+
+// serviceNameRegex matches the names services can have, s.t. they can be used as hostnames
+var serviceNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// validateServices returns an error if the job's services can't make up a running system
+func (j *Job) validateServices() error {
+	// Volumes are only resolved once containers are created, so catch malformed ones beforehand
+	for _, service := range j.services() {
+		for _, volume := range service.Volumes {
+			if _, err := resolveVolume(volume); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(j.Services) == 0 {
+		for _, healthcheck := range j.Healthchecks {
+			if healthcheck.Service != "" {
+				return fmt.Errorf("healthcheck on port %d specifies service %s, but the job has no services", healthcheck.Port, healthcheck.Service)
+			}
+		}
+		return nil
+	}
+
+	if len(j.Ports) != 0 || len(j.Env) != 0 || len(j.Volumes) != 0 || j.Command != nil || j.Entrypoint != nil || j.NetworkMode != "" {
+		return fmt.Errorf("ports, env, volumes, command, entrypoint and network mode have to be set per service if the job has services")
+	}
+
+	names := make(map[string]bool)
+	for _, service := range j.Services {
+		if !serviceNameRegex.MatchString(service.Name) {
+			return fmt.Errorf("invalid service name supplied: %q", service.Name)
+		}
+		if names[service.Name] {
+			return fmt.Errorf("duplicate service name supplied: %s", service.Name)
+		}
+		names[service.Name] = true
+	}
+	if j.mainService() == "" {
+		return fmt.Errorf("no service runs the image built from the bisected commit")
+	}
+
+	for _, healthcheck := range j.Healthchecks {
+		if healthcheck.Service != "" && !names[healthcheck.Service] {
+			return fmt.Errorf("healthcheck on port %d specifies unknown service %s", healthcheck.Port, healthcheck.Service)
+		}
+	}
+	return nil
+}
+
+// services returns the services making up the job's running systems.
+// If the job has no services, the running systems consist of a single unnamed service built from the bisected commit, configured by the job itself
+func (j *Job) services() []Service {
+	if len(j.Services) != 0 {
+		return j.Services
+	}
+	return []Service{{
+		Ports:      j.Ports,
+		Env:        j.Env,
+		Volumes:    j.Volumes,
+		Command:    j.Command,
+		Entrypoint: j.Entrypoint,
+	}}
+}
+
+// mainService returns the name of the first service built from the bisected commit, whose ports make up the ports of the running systems
+func (j *Job) mainService() string {
+	for _, service := range j.services() {
+		if service.Image == "" {
+			return service.Name
+		}
+	}
+	return ""
+}
+
+// healthcheckService returns the name of the service on which the passed healthcheck is performed
+func (j *Job) healthcheckService(healthcheck Healthcheck) string {
+	if healthcheck.Service == "" {
+		return j.mainService()
+	}
+	return healthcheck.Service
+}
+
+// A systemContainers holds the docker resources of a running system
+type systemContainers struct {
+	names   []string // The names of the system's containers, in the order they were started
+	network string   // The name of the system's private network, or empty if it has none
+}
+
+// startSystem starts the containers of the services of a running system of the passed commit, whose image has the passed name.
+// Returns the ports of each service mapped to the host, keyed by the name of the service.
+// If a container fails to start, the containers started before are stopped again
+func (j *Job) startSystem(apiClient *client.Client, commitHash string, imageName string) (systemContainers, map[string]map[int]int, error) {
+	var containers systemContainers
+
+	// Services reach each other on a private network, s.t. the services of concurrently running systems don't collide
+	if len(j.Services) != 0 {
+		containers.network = "biscepter-" + uniuri.New()
+		if _, err := apiClient.NetworkCreate(context.Background(), containers.network, types.NetworkCreate{
+			Labels: map[string]string{"biscepter": "1"},
+		}); err != nil {
+			return systemContainers{}, nil, errors.Join(fmt.Errorf("creation of network %s failed", containers.network), err)
+		}
+	}
+
+	servicePorts := make(map[string]map[int]int)
+	for _, service := range j.services() {
+		ports, err := j.startService(apiClient, service, commitHash, imageName, &containers)
+		if err != nil {
+			if err := containers.stop(apiClient); err != nil {
+				j.Log.Warnf("Failed to stop system of commit %s - %v", commitHash, err)
+			}
+			return systemContainers{}, nil, err
+		}
+		servicePorts[service.Name] = ports
+	}
+	return containers, servicePorts, nil
+}
+
+// startService starts the container of the passed service, adding it to the passed containers.
+// Returns the ports of the service mapped to the host
+func (j *Job) startService(apiClient *client.Client, service Service, commitHash string, imageName string, containers *systemContainers) (map[int]int, error) {
+	serviceImage := imageName
+	if service.Image != "" {
+		serviceImage = service.Image
+		if err := pullImage(apiClient, serviceImage); err != nil {
+			return nil, err
+		}
+	}
+
+	// Add all needed ports to the ports map
+	ports := make(map[int]int)
+	for _, healthcheck := range j.Healthchecks {
+		if j.healthcheckService(healthcheck) == service.Name {
+			ports[healthcheck.Port] = 0
+		}
+	}
+	for _, port := range service.Ports {
+		ports[port] = 0
+	}
+
+	// Assign free ports
+	exposedPorts := make(nat.PortSet)
+	portBindings := make(nat.PortMap)
+	for port := range ports {
+		if j.usesHostNetwork() {
+			// Containers using the host's network listen on the host's ports directly
+			ports[port] = port
+			continue
+		}
+
+		natPort := nat.Port(fmt.Sprint(port))
+
+		freePort, err := freeport.GetFreePort()
+		if err != nil {
+			return nil, err
+		}
+
+		exposedPorts[natPort] = struct{}{}
+		portBindings[natPort] = []nat.PortBinding{{HostIP: j.Host, HostPort: fmt.Sprint(freePort)}}
+		ports[port] = freePort
+	}
+
+	// Setup the container and host config
+	containerConfig, hostConfig, err := j.getContainerConfigs(service, commitHash, serviceImage, exposedPorts, portBindings)
+	if err != nil {
+		return nil, err
+	}
+	var networkingConfig *network.NetworkingConfig
+	if containers.network != "" {
+		hostConfig.NetworkMode = container.NetworkMode(containers.network)
+		networkingConfig = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				containers.network: {Aliases: []string{service.Name}},
+			},
+		}
+	}
+
+	containerName := "biscepter-" + uniuri.New()
+
+	j.Log.Debugf("Exposed ports of service %q: %+v, Port bindings: %+v", service.Name, exposedPorts, portBindings)
+
+	// Create the new container
+	resp, err := apiClient.ContainerCreate(context.Background(), containerConfig, hostConfig, networkingConfig, nil, containerName)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("container creation with name %s of image %s failed", containerName, serviceImage), err)
+	}
+
+	// Start the new container
+	if err := apiClient.ContainerStart(context.Background(), resp.ID, container.StartOptions{}); err != nil {
+		// The container isn't removed automatically if it never started
+		apiClient.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{})
+		return nil, errors.Join(fmt.Errorf("container start with name %s and id %s of image %s failed", containerName, resp.ID, serviceImage), err)
+	}
+	containers.names = append(containers.names, containerName)
+
+	return ports, nil
+}
+
+// pullImage pulls the image with the passed name, unless it is present already
+func pullImage(apiClient *client.Client, imageName string) error {
+	if _, _, err := apiClient.ImageInspectWithRaw(context.Background(), imageName); err == nil {
+		return nil
+	} else if !client.IsErrNotFound(err) {
+		return errors.Join(fmt.Errorf("inspection of image %s failed", imageName), err)
+	}
+
+	out, err := apiClient.ImagePull(context.Background(), imageName, image.PullOptions{})
+	if err != nil {
+		return errors.Join(fmt.Errorf("pull of image %s failed", imageName), err)
+	}
+	defer out.Close()
+
+	// Wait for the pull to be done
+	_, err = io.Copy(io.Discard, out)
+	return err
+}
+
+// stop stops the containers of the system in reverse order and removes its network
+func (c systemContainers) stop(apiClient *client.Client) error {
+	var errs []error
+	for i := len(c.names) - 1; i >= 0; i-- {
+		// The network can only be removed once the automatically removed containers are gone
+		removedChan, errChan := apiClient.ContainerWait(context.Background(), c.names[i], container.WaitConditionRemoved)
+		if err := apiClient.ContainerStop(context.Background(), c.names[i], container.StopOptions{}); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if c.network == "" {
+			continue
+		}
+		select {
+		case <-removedChan:
+		case err := <-errChan:
+			if !client.IsErrNotFound(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if c.network != "" {
+		if err := apiClient.NetworkRemove(context.Background(), c.network); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// servicePortsEnv returns the environment variables holding the passed ports of services, e.g. DB_PORT5432 for port 5432 of the service db
+func servicePortsEnv(servicePorts map[string]map[int]int) []string {
+	var env []string
+	for name, ports := range servicePorts {
+		if name == "" {
+			continue
+		}
+		prefix := strings.ToUpper(strings.Map(func(r rune) rune {
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return '_'
+		}, name))
+		for port, mapped := range ports {
+			env = append(env, fmt.Sprintf("%s_PORT%d=%d", prefix, port, mapped))
+		}
+	}
+	slices.Sort(env)
+	return env
+}
@@ -0,0 +1,64 @@
+package biscepter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateServices(t *testing.T) {
+	services := []Service{
+		{Name: "db", Image: "postgres:16", Ports: []int{5432}},
+		{Name: "app", Ports: []int{3333}},
+	}
+
+	assert.NoError(t, (&Job{Ports: []int{80}}).validateServices(), "Job without services is invalid")
+	assert.NoError(t, (&Job{Services: services, Healthchecks: []Healthcheck{{Port: 5432, Service: "db"}, {Port: 3333}}}).validateServices(), "Valid services are invalid")
+
+	invalid := map[string]*Job{
+		"healthcheck service without services": {Ports: []int{80}, Healthchecks: []Healthcheck{{Port: 80, Service: "app"}}},
+		"job ports":                            {Services: services, Ports: []int{80}},
+		"job env":                              {Services: services, Env: map[string]string{"A": "1"}},
+		"job network mode":                     {Services: services, NetworkMode: "host"},
+		"unnamed service":                      {Services: []Service{{}}},
+		"invalid service name":                 {Services: []Service{{Name: "my app"}}},
+		"duplicate service name":               {Services: []Service{{Name: "app"}, {Name: "app"}}},
+		"no built service":                     {Services: services[:1]},
+		"unknown healthcheck service":          {Services: services, Healthchecks: []Healthcheck{{Port: 6379, Service: "redis"}}},
+	}
+	for name, job := range invalid {
+		assert.Error(t, job.validateServices(), "Job with %s is valid", name)
+	}
+}
+
+func TestServices(t *testing.T) {
+	job := &Job{
+		Ports:   []int{80},
+		Env:     map[string]string{"A": "1"},
+		Command: []string{"serve"},
+	}
+	assert.Equal(t, []Service{{Ports: []int{80}, Env: map[string]string{"A": "1"}, Command: []string{"serve"}}}, job.services(), "Wrong implicit service")
+	assert.Equal(t, "", job.mainService(), "Wrong main service of implicit service")
+	assert.Equal(t, "", job.healthcheckService(Healthcheck{Port: 80}), "Wrong healthcheck service of implicit service")
+
+	job = &Job{
+		Services: []Service{
+			{Name: "db", Image: "postgres:16"},
+			{Name: "app"},
+			{Name: "worker"},
+		},
+	}
+	assert.Equal(t, job.Services, job.services(), "Wrong services")
+	assert.Equal(t, "app", job.mainService(), "Wrong main service")
+	assert.Equal(t, "app", job.healthcheckService(Healthcheck{Port: 80}), "Healthcheck without service isn't performed on main service")
+	assert.Equal(t, "db", job.healthcheckService(Healthcheck{Port: 5432, Service: "db"}), "Wrong healthcheck service")
+}
+
+func TestServicePortsEnv(t *testing.T) {
+	env := servicePortsEnv(map[string]map[int]int{
+		"":         {80: 1234},
+		"db":       {5432: 1235},
+		"my-cache": {6379: 1236, 6380: 1237},
+	})
+	assert.Equal(t, []string{"DB_PORT5432=1235", "MY_CACHE_PORT6379=1236", "MY_CACHE_PORT6380=1237"}, env, "Wrong service port environment variables")
+}
@@ -0,0 +1,103 @@
+package biscepter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// getStatePath returns the path to the state file of the replica.
+// The file name contains a hash of the job's config and the replica's issue, for differentiating the state files of different jobs
+func (r *replica) getStatePath() string {
+	hash := digest.FromString(strings.Join(append([]string{r.parentJob.Repository, r.parentJob.imageHash, r.issue.GoodCommit, r.issue.BadCommit}, r.issue.GoodCommits...), "\n")).Encoded()
+	return path.Join(r.parentJob.StateDirectory, fmt.Sprintf(".biscepter-state-%s-%d~", hash, r.index))
+}
+
+// initState opens the state file of the replica, to which every verdict given is written.
+// If restore is set, the verdicts stored in an existing state file are replayed first, restoring the replica's progress.
+// Otherwise, any existing state file is truncated.
+func (r *replica) initState(restore bool) error {
+	statePath := r.getStatePath()
+
+	flags := os.O_APPEND | os.O_WRONLY | os.O_CREATE
+	if restore {
+		state, err := os.ReadFile(statePath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Join(fmt.Errorf("couldn't read state file %s", statePath), err)
+		}
+		if err := r.restoreState(string(state)); err != nil {
+			return errors.Join(fmt.Errorf("couldn't restore state from state file %s", statePath), err)
+		}
+	} else {
+		flags |= os.O_TRUNC
+	}
+
+	var err error
+	r.stateFile, err = os.OpenFile(statePath, flags, 0644)
+	if err != nil {
+		return errors.Join(fmt.Errorf("couldn't open state file %s", statePath), err)
+	}
+	return nil
+}
+
+// restoreState replays the passed verdicts, as stored in a state file, to rebuild the replica's interval.
+func (r *replica) restoreState(state string) error {
+	entries := strings.Split(strings.TrimSuffix(state, ","), ",")
+	if entries[0] == "" {
+		return nil
+	}
+
+	for _, entry := range entries {
+		split := strings.Split(entry, ":")
+		if len(split) != 2 {
+			return fmt.Errorf("format of state file entry incorrect: %s", entry)
+		}
+		commit := split[0]
+
+		var verdict Verdict
+		switch split[1] {
+		case Good.String():
+			verdict = Good
+		case Bad.String():
+			verdict = Bad
+		default:
+			return fmt.Errorf("invalid verdict in state file entry: %s", entry)
+		}
+
+		// If the previous verdicts finished the bisection, it must have continued in a merged branch
+		if commitOffset, found := r.foundCommitOffset(); r.graph == nil && found {
+			commitHash := r.getActualCommit(r.commits[commitOffset])
+			prevCommitHash := r.getActualCommit(r.commits[commitOffset-1])
+			if !r.descendIntoMerge(commitHash, prevCommitHash) {
+				return fmt.Errorf("state contains verdict for commit %s after bisection was already finished", commit)
+			}
+		}
+
+		offset := slices.Index(r.commits, commit)
+		if offset == -1 {
+			return fmt.Errorf("commit %s of state file is not within the bisected commits", commit)
+		}
+
+		r.log.Debugf("Restoring verdict %s of commit %s", verdict, commit)
+		r.applyVerdict(offset, verdict)
+	}
+
+	r.log.Infof("Restored %d verdicts, good commit offset %d, bad commit offset %d", len(entries), r.goodCommitOffset, r.badCommitOffset)
+	return nil
+}
+
+// recordVerdict stores the passed verdict of the commit at the passed offset in the replica's state file.
+// If the replica has no state file, this is a no-op.
+func (r *replica) recordVerdict(commitOffset int, verdict Verdict) {
+	if r.stateFile == nil {
+		return
+	}
+	if _, err := r.stateFile.WriteString(fmt.Sprintf("%s:%s,", r.commits[commitOffset], verdict)); err != nil {
+		r.log.Warnf("Failed to write verdict to state file - %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package biscepter
+
+import (
+	"fmt"
+	"math"
+)
+
+// A ReplicaStatus is a snapshot of the progress of a replica's bisection
+type ReplicaStatus struct {
+	ReplicaIndex int // The index of the replica
+
+	GoodCommitOffset int // The offset of the newest good commit among the replica's current commits
+	BadCommitOffset  int // The offset of the oldest bad commit among the replica's current commits
+
+	GoodCommit string // The hash of the newest good commit among the replica's current commits
+	BadCommit  string // The hash of the oldest bad commit among the replica's current commits
+
+	RemainingCommits int     // The amount of commits which could still be the offending commit
+	ExpectedRunsLeft float64 // The expected amount of running systems which still have to be rated until the offending commit is found
+
+	RunningCommit string // The commit of the replica's running system awaiting a verdict, or empty if there is none
+
+	Finished bool // Whether the replica found the offending commit of its issue
+	Stopped  bool // Whether the replica was stopped or cancelled
+}
+
+// A ReplicaCommit is one of the commits a replica currently bisects
+type ReplicaCommit struct {
+	Hash string // The hash of the commit
+
+	Built bool // Whether an image of the commit was built before, s.t. it can be reused
+
+	Replacement string // The commit this commit is replaced with, or empty if it isn't replaced
+	Skipped     bool   // Whether the commit is replaced because the replica skipped it, instead of because it is broken
+}
+
+// ReplicaStatuses returns the status of every replica of the job, where the status at index i belongs to the replica with index i
+func (job *Job) ReplicaStatuses() []ReplicaStatus {
+	job.replicasMutex.Lock()
+	defer job.replicasMutex.Unlock()
+
+	statuses := make([]ReplicaStatus, len(job.replicas))
+	for i, replica := range job.replicas {
+		statuses[i] = replica.status()
+	}
+	return statuses
+}
+
+// GetReplicaStatus returns the status of the replica with the passed index.
+// If there is no replica with the passed index, an error wrapping [ErrReplicaNotFound] is returned.
+func (job *Job) GetReplicaStatus(index int) (ReplicaStatus, error) {
+	job.replicasMutex.Lock()
+	defer job.replicasMutex.Unlock()
+
+	if index < 0 || index >= len(job.replicas) {
+		return ReplicaStatus{}, fmt.Errorf("%w: no replica with index %d", ErrReplicaNotFound, index)
+	}
+	return job.replicas[index].status(), nil
+}
+
+// ReplicaCommits returns the commits currently bisected by the replica with the passed index, ordered from its good to its bad commit.
+// If there is no replica with the passed index, an error wrapping [ErrReplicaNotFound] is returned.
+func (job *Job) ReplicaCommits(index int) ([]ReplicaCommit, error) {
+	job.replicasMutex.Lock()
+	defer job.replicasMutex.Unlock()
+
+	if index < 0 || index >= len(job.replicas) {
+		return nil, fmt.Errorf("%w: no replica with index %d", ErrReplicaNotFound, index)
+	}
+	return job.replicas[index].replicaCommits(), nil
+}
+
+// status returns a snapshot of the replica's progress
+func (r *replica) status() ReplicaStatus {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	status := ReplicaStatus{
+		ReplicaIndex: r.index,
+
+		GoodCommitOffset: r.goodCommitOffset,
+		BadCommitOffset:  r.badCommitOffset,
+
+		RemainingCommits: r.remainingCommits(),
+
+		Finished: r.isFinished,
+		Stopped:  r.isStopped,
+	}
+	if r.goodCommitOffset >= 0 && r.goodCommitOffset < len(r.commits) {
+		status.GoodCommit = r.commits[r.goodCommitOffset]
+	}
+	if r.badCommitOffset >= 0 && r.badCommitOffset < len(r.commits) {
+		status.BadCommit = r.commits[r.badCommitOffset]
+	}
+	if status.RemainingCommits > 1 {
+		status.ExpectedRunsLeft = math.Log2(float64(status.RemainingCommits)) * float64(max(r.parentJob.Trials, 1))
+	}
+	if r.awaitingVerdict && r.lastRunningSystem != nil {
+		status.RunningCommit = r.lastRunningSystem.commit
+	}
+	return status
+}
+
+// remainingCommits returns the amount of the replica's current commits which could still be the offending commit
+func (r *replica) remainingCommits() int {
+	if r.graph != nil {
+		return r.graph.candidateCount()
+	}
+	if r.probabilities != nil {
+		remaining := 0
+		for _, p := range r.probabilities {
+			if p > 0 {
+				remaining++
+			}
+		}
+		return remaining
+	}
+	return r.badCommitOffset - r.goodCommitOffset
+}
+
+// replicaCommits returns a snapshot of the replica's current commits
+func (r *replica) replicaCommits() []ReplicaCommit {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	commits := make([]ReplicaCommit, len(r.commits))
+	for i, hash := range r.commits {
+		commits[i] = ReplicaCommit{Hash: hash}
+		replacement, broken := r.getBrokenReplacement(hash)
+		if broken {
+			commits[i].Replacement = replacement
+		} else if replacement, ok := r.skippedCommits[hash]; ok {
+			commits[i].Replacement = replacement
+			commits[i].Skipped = true
+		}
+		// Images of broken commits are marked as built, s.t. they aren't attempted to be built again
+		imageName := r.parentJob.getDockerImageOfCommit(hash)
+		commits[i].Built = !broken && !r.parentJob.ImageCache.isBroken(imageName) && r.parentJob.ImageCache.isBuilt(imageName)
+	}
+	return commits
+}
@@ -0,0 +1,121 @@
+package biscepter
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicaStatus(t *testing.T) {
+	job := &Job{Trials: 1}
+	job.replicas = []*replica{
+		{
+			index:            0,
+			parentJob:        job,
+			goodCommitOffset: 2,
+			badCommitOffset:  6,
+			commits:          []string{"good", "a", "b", "c", "d", "e", "bad"},
+			awaitingVerdict:  true,
+			lastRunningSystem: &RunningSystem{
+				commit: "c",
+			},
+			mutex: &sync.Mutex{},
+		},
+		{
+			index:           1,
+			parentJob:       job,
+			badCommitOffset: 5,
+			commits:         []string{"good", "a", "b", "c", "m", "d"},
+			graph:           newCommitGraph(testGraphParents),
+			isFinished:      true,
+			mutex:           &sync.Mutex{},
+		},
+	}
+
+	statuses := job.ReplicaStatuses()
+	assert.Len(t, statuses, 2, "Wrong amount of replica statuses")
+	assert.Equal(t, ReplicaStatus{
+		ReplicaIndex:     0,
+		GoodCommitOffset: 2,
+		BadCommitOffset:  6,
+		GoodCommit:       "b",
+		BadCommit:        "bad",
+		RemainingCommits: 4,
+		ExpectedRunsLeft: 2,
+		RunningCommit:    "c",
+	}, statuses[0], "Wrong status of linear replica")
+	assert.Equal(t, 5, statuses[1].RemainingCommits, "Wrong remaining commits of DAG replica")
+	assert.True(t, statuses[1].Finished, "Finished replica isn't reported as finished")
+	assert.Empty(t, statuses[1].RunningCommit, "Replica without running system reports a running commit")
+
+	status, err := job.GetReplicaStatus(1)
+	assert.Nil(t, err, "GetReplicaStatus returned an error")
+	assert.Equal(t, statuses[1], status, "Mismatch between replica statuses")
+
+	_, err = job.GetReplicaStatus(2)
+	assert.ErrorIs(t, err, ErrReplicaNotFound, "Getting the status of a non-existent replica didn't raise the right error")
+}
+
+func TestReplicaCommits(t *testing.T) {
+	job := &Job{
+		commitReplacements: &sync.Map{},
+		ImageCache:         &ImageCache{built: make(map[string]bool), broken: make(map[string]bool)},
+	}
+	job.commitReplacements.Store("b", "c")
+	job.ImageCache.built[job.getDockerImageOfCommit("a")] = true
+	job.ImageCache.built[job.getDockerImageOfCommit("b")] = true
+	// Broken by another job sharing the image cache
+	job.ImageCache.setBroken(job.getDockerImageOfCommit("bad"))
+	job.replicas = []*replica{
+		{
+			parentJob:      job,
+			commits:        []string{"good", "a", "b", "c", "bad"},
+			skippedCommits: map[string]string{"c": "a"},
+			mutex:          &sync.Mutex{},
+		},
+	}
+
+	commits, err := job.ReplicaCommits(0)
+	assert.NoError(t, err, "ReplicaCommits returned an error")
+	assert.Equal(t, []ReplicaCommit{
+		{Hash: "good"},
+		{Hash: "a", Built: true},
+		{Hash: "b", Replacement: "c"},
+		{Hash: "c", Replacement: "a", Skipped: true},
+		{Hash: "bad"},
+	}, commits, "Wrong commits of replica")
+
+	_, err = job.ReplicaCommits(1)
+	assert.ErrorIs(t, err, ErrReplicaNotFound, "Getting the commits of a non-existent replica didn't raise the right error")
+}
+
+func TestReplicaStatusWhileBisecting(t *testing.T) {
+	job := &Job{Trials: 1}
+	rep := &replica{
+		index:           0,
+		parentJob:       job,
+		badCommitOffset: 5,
+		commits:         []string{"good", "a", "b", "c", "m", "d"},
+		graph:           newCommitGraph(testGraphParents),
+		mutex:           &sync.Mutex{},
+	}
+	job.replicas = []*replica{rep}
+
+	// Snapshots are taken concurrently to the replica's progress changing, which the race detector catches if unguarded
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rep.applyVerdict(4, Bad)
+		rep.applyVerdict(1, Good)
+		rep.setCommits([]string{"good", "x", "bad"})
+	}()
+	for range 100 {
+		job.ReplicaStatuses()
+	}
+	<-done
+
+	status := job.ReplicaStatuses()[0]
+	assert.Equal(t, "good", status.GoodCommit, "Wrong good commit after setting commits")
+	assert.Equal(t, "bad", status.BadCommit, "Wrong bad commit after setting commits")
+}
@@ -0,0 +1,91 @@
+package biscepter
+
+import (
+	"context"
+	"sync"
+)
+
+// A JobSummary holds the results of a job's replicas, e.g. for reporting them once the job is stopped
+type JobSummary struct {
+	OffendingCommits   []OffendingCommit // The offending commits found by the job's replicas, ordered by replica index
+	UnfinishedReplicas []ReplicaStatus   // The statuses of the replicas which didn't find their offending commit, including their current good and bad commits
+}
+
+// Summary returns the offending commits found by the job's replicas so far, and the statuses of the replicas which are still bisecting or were stopped before finishing.
+// Offending commits are included regardless of whether they were received from the channel returned by [Job.Run].
+func (job *Job) Summary() JobSummary {
+	job.replicasMutex.Lock()
+	defer job.replicasMutex.Unlock()
+
+	summary := JobSummary{
+		OffendingCommits:   make([]OffendingCommit, 0),
+		UnfinishedReplicas: make([]ReplicaStatus, 0),
+	}
+	for _, replica := range job.replicas {
+		status := replica.status()
+
+		replica.mutex.Lock()
+		offendingCommit := replica.offendingCommit
+		replica.mutex.Unlock()
+
+		if offendingCommit != nil {
+			summary.OffendingCommits = append(summary.OffendingCommits, *offendingCommit)
+		} else {
+			summary.UnfinishedReplicas = append(summary.UnfinishedReplicas, status)
+		}
+	}
+	return summary
+}
+
+// WaitForBuilds blocks until none of the job's replicas is building an image, or until the passed context is done, in which case its error is returned.
+// Call this before [Job.Stop] to not abort images which are being built, s.t. subsequent runs can reuse them.
+func (job *Job) WaitForBuilds(ctx context.Context) error {
+	return job.builds.wait(ctx)
+}
+
+// A buildTracker keeps count of the images being built, allowing to wait until none is being built
+type buildTracker struct {
+	mutex sync.Mutex
+	count int
+	idle  chan struct{} // Closed once no image is being built anymore
+}
+
+// start registers an image build which started
+func (b *buildTracker) start() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.count == 0 {
+		b.idle = make(chan struct{})
+	}
+	b.count++
+}
+
+// finish registers an image build which finished, regardless of whether it succeeded
+func (b *buildTracker) finish() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.count--
+	if b.count == 0 {
+		close(b.idle)
+	}
+}
+
+// wait blocks until no image is being built, or until the passed context is done
+func (b *buildTracker) wait(ctx context.Context) error {
+	b.mutex.Lock()
+	if b.count == 0 {
+		b.mutex.Unlock()
+		return nil
+	}
+	idle := b.idle
+	b.mutex.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,55 @@
+package biscepter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobSummary(t *testing.T) {
+	job := &Job{Trials: 1}
+	job.replicas = []*replica{
+		{
+			index:            0,
+			parentJob:        job,
+			goodCommitOffset: 1,
+			badCommitOffset:  3,
+			commits:          []string{"good", "a", "b", "bad"},
+			isStopped:        true,
+			mutex:            &sync.Mutex{},
+		},
+		{
+			index:           1,
+			parentJob:       job,
+			isFinished:      true,
+			offendingCommit: &OffendingCommit{ReplicaIndex: 1, Commit: "b"},
+			mutex:           &sync.Mutex{},
+		},
+	}
+
+	summary := job.Summary()
+	assert.Equal(t, []OffendingCommit{{ReplicaIndex: 1, Commit: "b"}}, summary.OffendingCommits, "Wrong offending commits in summary")
+	if assert.Len(t, summary.UnfinishedReplicas, 1, "Wrong amount of unfinished replicas in summary") {
+		assert.Equal(t, "a", summary.UnfinishedReplicas[0].GoodCommit, "Wrong good commit of unfinished replica")
+		assert.Equal(t, "bad", summary.UnfinishedReplicas[0].BadCommit, "Wrong bad commit of unfinished replica")
+	}
+}
+
+func TestWaitForBuilds(t *testing.T) {
+	job := &Job{}
+	assert.NoError(t, job.WaitForBuilds(context.Background()), "Waiting without builds failed")
+
+	job.builds.start()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, job.WaitForBuilds(ctx), context.DeadlineExceeded, "Waiting didn't block while building")
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		job.builds.finish()
+	}()
+	assert.NoError(t, job.WaitForBuilds(context.Background()), "Waiting after build finished failed")
+}
@@ -0,0 +1,87 @@
+package biscepter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+type testYaml struct {
+	Script string `yaml:"script"`
+
+	// Pointers, s.t. an explicit exit code of 0 isn't replaced by the default
+	BadExitCode    *int `yaml:"badExitCode" default:"1"`
+	BrokenExitCode *int `yaml:"brokenExitCode" default:"125"`
+}
+
+// Verdict specifies the rating a running system received
+type Verdict int
+
+const (
+	// The running system does not exhibit the issue
+	Good Verdict = iota
+	// The running system exhibits the issue
+	Bad
+	// The running system's commit is broken and should be avoided from now on
+	Broken
+)
+
+// String returns the lowercase name of the verdict
+func (v Verdict) String() string {
+	switch v {
+	case Good:
+		return "good"
+	case Bad:
+		return "bad"
+	case Broken:
+		return "broken"
+	}
+	return fmt.Sprintf("verdict(%d)", int(v))
+}
+
+// A Test is a script which is ran against every running system to automatically determine its [Verdict], similarly to `git bisect run`.
+type Test struct {
+	// The script to run in sh. An exit code of 0 means the running system is good.
+	// The environment variable `$PORT<XXXX>` can be used within the script to get the port to which `<XXXX>` was mapped to on the host (e.g. `$PORT443`).
+	// If the job has services, `$<SERVICE>_PORT<XXXX>` holds the port to which port `<XXXX>` of a service was mapped to (e.g. `$DB_PORT5432` for the service db)
+	Script string
+
+	BadExitCode    int // The exit code of the script signaling that the running system is bad
+	BrokenExitCode int // The exit code of the script signaling that the running system is broken and its commit should be avoided
+}
+
+// performTest runs the test script against the passed port mappings and returns the resulting verdict.
+// An error is returned if the script could not be run or exited with an exit code not corresponding to any verdict.
+func (t Test) performTest(portsMapping map[int]int, servicePorts map[string]map[int]int) (Verdict, []byte, error) {
+	cmd := exec.Command("sh", "-c", t.Script)
+	out := new(bytes.Buffer)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	// Set the ports mapping env variables
+	cmd.Env = os.Environ()
+	for k, v := range portsMapping {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("PORT%d=%d", k, v))
+	}
+	cmd.Env = append(cmd.Env, servicePortsEnv(servicePorts)...)
+
+	err := cmd.Run()
+	if err == nil {
+		return Good, out.Bytes(), nil
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return 0, out.Bytes(), errors.Join(fmt.Errorf("failed to run test script"), err)
+	}
+
+	switch exitErr.ExitCode() {
+	case t.BadExitCode:
+		return Bad, out.Bytes(), nil
+	case t.BrokenExitCode:
+		return Broken, out.Bytes(), nil
+	}
+	return 0, out.Bytes(), fmt.Errorf("test script exited with unexpected exit code %d, output: %s", exitErr.ExitCode(), out)
+}
@@ -0,0 +1,55 @@
+package biscepter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerformTest(t *testing.T) {
+	test := Test{
+		BadExitCode:    1,
+		BrokenExitCode: 125,
+	}
+
+	t.Run("Exit code 0 is good", func(t *testing.T) {
+		test.Script = "exit 0"
+		verdict, _, err := test.performTest(map[int]int{}, nil)
+		assert.NoError(t, err, "Successful test script resulted in an error")
+		assert.Equal(t, Good, verdict, "Wrong verdict for successful test script")
+	})
+	t.Run("Bad exit code is bad", func(t *testing.T) {
+		test.Script = "exit 1"
+		verdict, _, err := test.performTest(map[int]int{}, nil)
+		assert.NoError(t, err, "Test script with bad exit code resulted in an error")
+		assert.Equal(t, Bad, verdict, "Wrong verdict for test script with bad exit code")
+	})
+	t.Run("Broken exit code is broken", func(t *testing.T) {
+		test.Script = "exit 125"
+		verdict, _, err := test.performTest(map[int]int{}, nil)
+		assert.NoError(t, err, "Test script with broken exit code resulted in an error")
+		assert.Equal(t, Broken, verdict, "Wrong verdict for test script with broken exit code")
+	})
+	t.Run("Unexpected exit code errors", func(t *testing.T) {
+		test.Script = "exit 2"
+		_, _, err := test.performTest(map[int]int{}, nil)
+		assert.Error(t, err, "Test script with unexpected exit code didn't result in an error")
+	})
+	t.Run("Port environment variable gets substituted correctly", func(t *testing.T) {
+		test.Script = "if [ $PORT1337 -eq 42 ]; then exit 0; fi; exit 1"
+		verdict, _, err := test.performTest(map[int]int{
+			1337: 42,
+		}, nil)
+		assert.NoError(t, err, "Test script resulted in an error")
+		assert.Equal(t, Good, verdict, "Port environment variable wasn't substituted correctly")
+	})
+	t.Run("Service port environment variables get substituted correctly", func(t *testing.T) {
+		test.Script = "if [ $DB_PORT5432 -eq 42 ] && [ $MY_CACHE_PORT6379 -eq 43 ]; then exit 0; fi; exit 1"
+		verdict, _, err := test.performTest(map[int]int{}, map[string]map[int]int{
+			"db":       {5432: 42},
+			"my-cache": {6379: 43},
+		})
+		assert.NoError(t, err, "Test script resulted in an error")
+		assert.Equal(t, Good, verdict, "Service port environment variables weren't substituted correctly")
+	})
+}
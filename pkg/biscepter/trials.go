@@ -0,0 +1,85 @@
+package biscepter
+
+// TrialAggregation specifies how the verdicts of the trials of a commit are aggregated into the commit's verdict if a job has more than one trial
+type TrialAggregation int
+
+const (
+	// A commit is bad if any of its trials is bad
+	TrialAggregationAnyBad TrialAggregation = iota
+	// A commit is bad if more than half of its trials are bad
+	TrialAggregationMajority
+	// A commit is bad only if all of its trials are bad
+	TrialAggregationAllBad
+)
+
+// aggregateVerdicts aggregates the passed good and bad verdicts of a commit's trials according to the passed aggregation.
+// Returns the aggregated verdict and whether it is decided, i.e. whether the verdicts of the remaining trials can no longer change it.
+func aggregateVerdicts(verdicts []Verdict, trials int, aggregation TrialAggregation) (Verdict, bool) {
+	good, bad := 0, 0
+	for _, verdict := range verdicts {
+		switch verdict {
+		case Good:
+			good++
+		case Bad:
+			bad++
+		}
+	}
+	remaining := trials - good - bad
+
+	switch aggregation {
+	case TrialAggregationMajority:
+		if bad*2 > trials {
+			return Bad, true
+		}
+		if good*2 >= trials {
+			return Good, true
+		}
+	case TrialAggregationAllBad:
+		if good > 0 {
+			return Good, true
+		}
+		if remaining <= 0 {
+			return Bad, true
+		}
+	default:
+		if bad > 0 {
+			return Bad, true
+		}
+		if remaining <= 0 {
+			return Good, true
+		}
+	}
+	return 0, false
+}
+
+// concludeTrial adds the passed verdict to the verdicts of the trials of the passed running system's commit.
+// Once the trials decide the commit's verdict according to the job's trial aggregation, the aggregated verdict is applied and recorded.
+// Otherwise, the next system initialized by the replica is another trial of the same commit.
+func (r *replica) concludeTrial(rs RunningSystem, verdict Verdict) {
+	r.trialVerdicts = append(r.trialVerdicts, verdict)
+	r.trialCommitOffset = rs.commitRootOffset
+
+	aggregated, decided := aggregateVerdicts(r.trialVerdicts, r.parentJob.Trials, r.parentJob.TrialAggregation)
+	if r.parentJob.Trials > 1 {
+		r.log.Infof("Trial %d/%d of commit %s rated %s", rs.TrialIndex+1, r.parentJob.Trials, rs.commit, verdict)
+	}
+	if !decided {
+		return
+	}
+	if r.parentJob.Trials > 1 {
+		r.log.Infof("Trials of commit %s aggregated to %s after %d trials", rs.commit, aggregated, len(r.trialVerdicts))
+	}
+
+	r.trialVerdicts = nil
+	r.applyVerdict(rs.commitRootOffset, aggregated)
+	r.recordVerdict(rs.commitRootOffset, aggregated)
+
+	r.parentJob.publishEvent(Event{
+		Type: EventVerdictRecorded,
+
+		ReplicaIndex: r.index,
+		Commit:       rs.commit,
+
+		Verdict: aggregated,
+	})
+}
@@ -0,0 +1,38 @@
+package biscepter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateVerdicts(t *testing.T) {
+	values := []struct {
+		verdicts    []Verdict
+		trials      int
+		aggregation TrialAggregation
+
+		expectedVerdict Verdict
+		expectedDecided bool
+	}{
+		{[]Verdict{Good}, 1, TrialAggregationAnyBad, Good, true},
+		{[]Verdict{Good}, 3, TrialAggregationAnyBad, 0, false},
+		{[]Verdict{Good, Bad}, 3, TrialAggregationAnyBad, Bad, true},
+		{[]Verdict{Good, Good, Good}, 3, TrialAggregationAnyBad, Good, true},
+		{[]Verdict{Bad}, 3, TrialAggregationMajority, 0, false},
+		{[]Verdict{Bad, Good, Bad}, 3, TrialAggregationMajority, Bad, true},
+		{[]Verdict{Good, Good}, 3, TrialAggregationMajority, Good, true},
+		{[]Verdict{Bad, Good, Good, Bad}, 4, TrialAggregationMajority, Good, true},
+		{[]Verdict{Bad, Bad}, 3, TrialAggregationAllBad, 0, false},
+		{[]Verdict{Bad, Good}, 3, TrialAggregationAllBad, Good, true},
+		{[]Verdict{Bad, Bad, Bad}, 3, TrialAggregationAllBad, Bad, true},
+	}
+
+	for i, v := range values {
+		verdict, decided := aggregateVerdicts(v.verdicts, v.trials, v.aggregation)
+		assert.Equalf(t, v.expectedDecided, decided, "aggregateVerdicts returned wrong decision for test %d; verdicts: %v", i, v.verdicts)
+		if v.expectedDecided {
+			assert.Equalf(t, v.expectedVerdict, verdict, "aggregateVerdicts returned wrong verdict for test %d; verdicts: %v", i, v.verdicts)
+		}
+	}
+}
@@ -0,0 +1,488 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: biscepter.proto
+
+// Interact with a running biscepter process for bisecting issues.
+// Mirrors the HTTP API specified in openapi.yml.
+
+package biscepterpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Biscepter_GetSystem_FullMethodName     = "/biscepter.v1.Biscepter/GetSystem"
+	Biscepter_Rate_FullMethodName          = "/biscepter.v1.Biscepter/Rate"
+	Biscepter_RenewLease_FullMethodName    = "/biscepter.v1.Biscepter/RenewLease"
+	Biscepter_Bisect_FullMethodName        = "/biscepter.v1.Biscepter/Bisect"
+	Biscepter_WatchEvents_FullMethodName   = "/biscepter.v1.Biscepter/WatchEvents"
+	Biscepter_ListReplicas_FullMethodName  = "/biscepter.v1.Biscepter/ListReplicas"
+	Biscepter_GetReplica_FullMethodName    = "/biscepter.v1.Biscepter/GetReplica"
+	Biscepter_AddReplica_FullMethodName    = "/biscepter.v1.Biscepter/AddReplica"
+	Biscepter_CancelReplica_FullMethodName = "/biscepter.v1.Biscepter/CancelReplica"
+	Biscepter_Stop_FullMethodName          = "/biscepter.v1.Biscepter/Stop"
+)
+
+// BiscepterClient is the client API for Biscepter service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BiscepterClient interface {
+	// Get the next running system or offending commit.
+	// If neither got ready within the timeout, the response contains neither.
+	GetSystem(ctx context.Context, in *GetSystemRequest, opts ...grpc.CallOption) (*GetSystemResponse, error)
+	// Give a running system a verdict. Repeating a verdict succeeds without rating the system again,
+	// whereas giving a different verdict fails with ABORTED. Systems whose lease expired fail with FAILED_PRECONDITION
+	Rate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error)
+	// Renew the lease of a running system
+	RenewLease(ctx context.Context, in *RenewLeaseRequest, opts ...grpc.CallOption) (*RenewLeaseResponse, error)
+	// Bisect over a single stream, requesting running systems and rating them as they are tested
+	Bisect(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BisectRequest, BisectResponse], error)
+	// Stream the events of the running job until the server is stopped
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// Get the status of every replica of the running job
+	ListReplicas(ctx context.Context, in *ListReplicasRequest, opts ...grpc.CallOption) (*ListReplicasResponse, error)
+	// Get the status of a replica
+	GetReplica(ctx context.Context, in *GetReplicaRequest, opts ...grpc.CallOption) (*ReplicaStatus, error)
+	// Add a new replica bisecting an issue to the running job
+	AddReplica(ctx context.Context, in *Issue, opts ...grpc.CallOption) (*AddReplicaResponse, error)
+	// Cancel a replica, abandoning the bisection of its issue without affecting other replicas
+	CancelReplica(ctx context.Context, in *CancelReplicaRequest, opts ...grpc.CallOption) (*CancelReplicaResponse, error)
+	// Stop the current running job, returning a summary of its results
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+}
+
+type biscepterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBiscepterClient(cc grpc.ClientConnInterface) BiscepterClient {
+	return &biscepterClient{cc}
+}
+
+func (c *biscepterClient) GetSystem(ctx context.Context, in *GetSystemRequest, opts ...grpc.CallOption) (*GetSystemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSystemResponse)
+	err := c.cc.Invoke(ctx, Biscepter_GetSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biscepterClient) Rate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateResponse)
+	err := c.cc.Invoke(ctx, Biscepter_Rate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biscepterClient) RenewLease(ctx context.Context, in *RenewLeaseRequest, opts ...grpc.CallOption) (*RenewLeaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenewLeaseResponse)
+	err := c.cc.Invoke(ctx, Biscepter_RenewLease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biscepterClient) Bisect(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BisectRequest, BisectResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Biscepter_ServiceDesc.Streams[0], Biscepter_Bisect_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BisectRequest, BisectResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Biscepter_BisectClient = grpc.BidiStreamingClient[BisectRequest, BisectResponse]
+
+func (c *biscepterClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Biscepter_ServiceDesc.Streams[1], Biscepter_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Biscepter_WatchEventsClient = grpc.ServerStreamingClient[Event]
+
+func (c *biscepterClient) ListReplicas(ctx context.Context, in *ListReplicasRequest, opts ...grpc.CallOption) (*ListReplicasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReplicasResponse)
+	err := c.cc.Invoke(ctx, Biscepter_ListReplicas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biscepterClient) GetReplica(ctx context.Context, in *GetReplicaRequest, opts ...grpc.CallOption) (*ReplicaStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplicaStatus)
+	err := c.cc.Invoke(ctx, Biscepter_GetReplica_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biscepterClient) AddReplica(ctx context.Context, in *Issue, opts ...grpc.CallOption) (*AddReplicaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReplicaResponse)
+	err := c.cc.Invoke(ctx, Biscepter_AddReplica_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biscepterClient) CancelReplica(ctx context.Context, in *CancelReplicaRequest, opts ...grpc.CallOption) (*CancelReplicaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelReplicaResponse)
+	err := c.cc.Invoke(ctx, Biscepter_CancelReplica_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biscepterClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopResponse)
+	err := c.cc.Invoke(ctx, Biscepter_Stop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BiscepterServer is the server API for Biscepter service.
+// All implementations must embed UnimplementedBiscepterServer
+// for forward compatibility.
+type BiscepterServer interface {
+	// Get the next running system or offending commit.
+	// If neither got ready within the timeout, the response contains neither.
+	GetSystem(context.Context, *GetSystemRequest) (*GetSystemResponse, error)
+	// Give a running system a verdict. Repeating a verdict succeeds without rating the system again,
+	// whereas giving a different verdict fails with ABORTED. Systems whose lease expired fail with FAILED_PRECONDITION
+	Rate(context.Context, *RateRequest) (*RateResponse, error)
+	// Renew the lease of a running system
+	RenewLease(context.Context, *RenewLeaseRequest) (*RenewLeaseResponse, error)
+	// Bisect over a single stream, requesting running systems and rating them as they are tested
+	Bisect(grpc.BidiStreamingServer[BisectRequest, BisectResponse]) error
+	// Stream the events of the running job until the server is stopped
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error
+	// Get the status of every replica of the running job
+	ListReplicas(context.Context, *ListReplicasRequest) (*ListReplicasResponse, error)
+	// Get the status of a replica
+	GetReplica(context.Context, *GetReplicaRequest) (*ReplicaStatus, error)
+	// Add a new replica bisecting an issue to the running job
+	AddReplica(context.Context, *Issue) (*AddReplicaResponse, error)
+	// Cancel a replica, abandoning the bisection of its issue without affecting other replicas
+	CancelReplica(context.Context, *CancelReplicaRequest) (*CancelReplicaResponse, error)
+	// Stop the current running job, returning a summary of its results
+	Stop(context.Context, *StopRequest) (*StopResponse, error)
+	mustEmbedUnimplementedBiscepterServer()
+}
+
+// UnimplementedBiscepterServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBiscepterServer struct{}
+
+func (UnimplementedBiscepterServer) GetSystem(context.Context, *GetSystemRequest) (*GetSystemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSystem not implemented")
+}
+func (UnimplementedBiscepterServer) Rate(context.Context, *RateRequest) (*RateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rate not implemented")
+}
+func (UnimplementedBiscepterServer) RenewLease(context.Context, *RenewLeaseRequest) (*RenewLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewLease not implemented")
+}
+func (UnimplementedBiscepterServer) Bisect(grpc.BidiStreamingServer[BisectRequest, BisectResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Bisect not implemented")
+}
+func (UnimplementedBiscepterServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedBiscepterServer) ListReplicas(context.Context, *ListReplicasRequest) (*ListReplicasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReplicas not implemented")
+}
+func (UnimplementedBiscepterServer) GetReplica(context.Context, *GetReplicaRequest) (*ReplicaStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplica not implemented")
+}
+func (UnimplementedBiscepterServer) AddReplica(context.Context, *Issue) (*AddReplicaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReplica not implemented")
+}
+func (UnimplementedBiscepterServer) CancelReplica(context.Context, *CancelReplicaRequest) (*CancelReplicaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReplica not implemented")
+}
+func (UnimplementedBiscepterServer) Stop(context.Context, *StopRequest) (*StopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (UnimplementedBiscepterServer) mustEmbedUnimplementedBiscepterServer() {}
+func (UnimplementedBiscepterServer) testEmbeddedByValue()                   {}
+
+// UnsafeBiscepterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BiscepterServer will
+// result in compilation errors.
+type UnsafeBiscepterServer interface {
+	mustEmbedUnimplementedBiscepterServer()
+}
+
+func RegisterBiscepterServer(s grpc.ServiceRegistrar, srv BiscepterServer) {
+	// If the following call pancis, it indicates UnimplementedBiscepterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Biscepter_ServiceDesc, srv)
+}
+
+func _Biscepter_GetSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSystemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiscepterServer).GetSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biscepter_GetSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiscepterServer).GetSystem(ctx, req.(*GetSystemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biscepter_Rate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiscepterServer).Rate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biscepter_Rate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiscepterServer).Rate(ctx, req.(*RateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biscepter_RenewLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiscepterServer).RenewLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biscepter_RenewLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiscepterServer).RenewLease(ctx, req.(*RenewLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biscepter_Bisect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BiscepterServer).Bisect(&grpc.GenericServerStream[BisectRequest, BisectResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Biscepter_BisectServer = grpc.BidiStreamingServer[BisectRequest, BisectResponse]
+
+func _Biscepter_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BiscepterServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Biscepter_WatchEventsServer = grpc.ServerStreamingServer[Event]
+
+func _Biscepter_ListReplicas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReplicasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiscepterServer).ListReplicas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biscepter_ListReplicas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiscepterServer).ListReplicas(ctx, req.(*ListReplicasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biscepter_GetReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplicaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiscepterServer).GetReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biscepter_GetReplica_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiscepterServer).GetReplica(ctx, req.(*GetReplicaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biscepter_AddReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Issue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiscepterServer).AddReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biscepter_AddReplica_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiscepterServer).AddReplica(ctx, req.(*Issue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biscepter_CancelReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReplicaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiscepterServer).CancelReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biscepter_CancelReplica_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiscepterServer).CancelReplica(ctx, req.(*CancelReplicaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Biscepter_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiscepterServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Biscepter_Stop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiscepterServer).Stop(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Biscepter_ServiceDesc is the grpc.ServiceDesc for Biscepter service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Biscepter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "biscepter.v1.Biscepter",
+	HandlerType: (*BiscepterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSystem",
+			Handler:    _Biscepter_GetSystem_Handler,
+		},
+		{
+			MethodName: "Rate",
+			Handler:    _Biscepter_Rate_Handler,
+		},
+		{
+			MethodName: "RenewLease",
+			Handler:    _Biscepter_RenewLease_Handler,
+		},
+		{
+			MethodName: "ListReplicas",
+			Handler:    _Biscepter_ListReplicas_Handler,
+		},
+		{
+			MethodName: "GetReplica",
+			Handler:    _Biscepter_GetReplica_Handler,
+		},
+		{
+			MethodName: "AddReplica",
+			Handler:    _Biscepter_AddReplica_Handler,
+		},
+		{
+			MethodName: "CancelReplica",
+			Handler:    _Biscepter_CancelReplica_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _Biscepter_Stop_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Bisect",
+			Handler:       _Biscepter_Bisect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Biscepter_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "biscepter.proto",
+}
@@ -0,0 +1,5 @@
+// Package biscepterpb contains the protobuf messages and gRPC service of biscepter's gRPC API, generated from api/biscepter.proto.
+// Clients of a server started with `biscepter bisect --server grpc` can use [NewBiscepterClient] to bisect issues.
+package biscepterpb
+
+//go:generate protoc -I ../../api --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative biscepter.proto